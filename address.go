@@ -1,7 +1,14 @@
 package iota
 
 import (
+	"crypto/ed25519"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+
+	"golang.org/x/crypto/blake2b"
 )
 
 // Defines the type of addresses.
@@ -12,6 +19,10 @@ const (
 	AddressWOTS AddressType = iota
 	// Denotes a Ed25510 address.
 	AddressEd25519
+	// Denotes an alias address.
+	AddressAlias
+	// Denotes an NFT address.
+	AddressNFT
 
 	// The length of a WOTS address.
 	WOTSAddressBytesLength = 49
@@ -22,6 +33,16 @@ const (
 	Ed25519AddressBytesLength = 32
 	// The size of a serialized Ed25519 address with its type denoting byte.
 	Ed25519AddressSerializedBytesSize = SmallTypeDenotationByteSize + Ed25519AddressBytesLength
+
+	// The length of an alias address, which is the ID of the alias.
+	AliasAddressBytesLength = 20
+	// The size of a serialized alias address with its type denoting byte.
+	AliasAddressSerializedBytesSize = SmallTypeDenotationByteSize + AliasAddressBytesLength
+
+	// The length of an NFT address, which is the ID of the NFT.
+	NFTAddressBytesLength = 20
+	// The size of a serialized NFT address with its type denoting byte.
+	NFTAddressSerializedBytesSize = SmallTypeDenotationByteSize + NFTAddressBytesLength
 )
 
 // AddressSelector implements SerializableSelectorFunc for address types.
@@ -32,6 +53,10 @@ func AddressSelector(typeByte uint32) (Serializable, error) {
 		seri = &WOTSAddress{}
 	case AddressEd25519:
 		seri = &Ed25519Address{}
+	case AddressAlias:
+		seri = &AliasAddress{}
+	case AddressNFT:
+		seri = &NFTAddress{}
 	default:
 		return nil, fmt.Errorf("%w: type %d", ErrUnknownAddrType, typeByte)
 	}
@@ -41,6 +66,21 @@ func AddressSelector(typeByte uint32) (Serializable, error) {
 // Defines a WOTS address.
 type WOTSAddress [WOTSAddressBytesLength]byte
 
+// Equal tells whether the given address is the same address.
+func (wotsAddr *WOTSAddress) Equal(other *WOTSAddress) bool {
+	return other != nil && *wotsAddr == *other
+}
+
+// Bech32 encodes the address in its Bech32 form using the given human-readable part.
+// With the network prefixes the string is longer than the 90 characters BIP-173 allows, but ParseBech32 accepts it.
+func (wotsAddr *WOTSAddress) Bech32(hrp string) (string, error) {
+	addrBytes, err := wotsAddr.Serialize(DeSeriModeNoValidation)
+	if err != nil {
+		return "", err
+	}
+	return bech32Encode(hrp, addrBytes)
+}
+
 func (wotsAddr *WOTSAddress) Deserialize(data []byte, deSeriMode DeSerializationMode) (int, error) {
 	if deSeriMode.HasMode(DeSeriModePerformValidation) {
 		if err := checkMinByteLength(WOTSAddressSerializedBytesSize, len(data)); err != nil {
@@ -51,23 +91,82 @@ func (wotsAddr *WOTSAddress) Deserialize(data []byte, deSeriMode DeSerialization
 		}
 		// TODO: check T5B1 encoding
 	}
-	copy(wotsAddr[:], data[SmallTypeDenotationByteSize:])
-	return WOTSAddressSerializedBytesSize, nil
+	return NewDeserializer(data).
+		Skip(SmallTypeDenotationByteSize, "WOTS address type").
+		ReadBytes(wotsAddr[:], "WOTS address").
+		Done()
 }
 
-func (wotsAddr *WOTSAddress) Serialize(deSeriMode DeSerializationMode) (data []byte, err error) {
+func (wotsAddr *WOTSAddress) ValidateBytes(data []byte, deSeriMode DeSerializationMode) (int, error) {
 	if deSeriMode.HasMode(DeSeriModePerformValidation) {
-		// TODO: check T5B1 encoding
+		if err := checkTypeByte(data, AddressWOTS); err != nil {
+			return 0, fmt.Errorf("invalid WOTS address bytes: %w", err)
+		}
 	}
+	return NewDeserializer(data).
+		Skip(WOTSAddressSerializedBytesSize, "WOTS address").
+		Done()
+}
+
+func (wotsAddr *WOTSAddress) Serialize(deSeriMode DeSerializationMode) (data []byte, err error) {
 	var b [WOTSAddressSerializedBytesSize]byte
-	b[0] = AddressWOTS
-	copy(b[SmallTypeDenotationByteSize:], wotsAddr[:])
+	if _, err := wotsAddr.SerializeInto(b[:], deSeriMode); err != nil {
+		return nil, err
+	}
 	return b[:], nil
 }
 
+// WriteTo writes the serialized WOTSAddress to w without allocating, implementing io.WriterTo.
+func (wotsAddr *WOTSAddress) WriteTo(w io.Writer) (int64, error) {
+	var b [WOTSAddressSerializedBytesSize]byte
+	return writeFixedSizeTo(w, wotsAddr, b[:])
+}
+
+func (wotsAddr *WOTSAddress) SerializeInto(buf []byte, deSeriMode DeSerializationMode) (int, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		// TODO: check T5B1 encoding
+	}
+	if err := checkSerializationBufferSize(WOTSAddressSerializedBytesSize, len(buf)); err != nil {
+		return 0, err
+	}
+	buf[0] = AddressWOTS
+	copy(buf[SmallTypeDenotationByteSize:], wotsAddr[:])
+	return WOTSAddressSerializedBytesSize, nil
+}
+
+func (wotsAddr *WOTSAddress) SerializedSize() int {
+	return WOTSAddressSerializedBytesSize
+}
+
 // Defines an Ed25519 address.
 type Ed25519Address [Ed25519AddressBytesLength]byte
 
+// Equal tells whether the given address is the same address.
+func (edAddr *Ed25519Address) Equal(other *Ed25519Address) bool {
+	return other != nil && *edAddr == *other
+}
+
+// AddressFromEd25519PubKey returns the address belonging to the given Ed25519 public key, which is the BLAKE2b-256 hash of the key.
+func AddressFromEd25519PubKey(pubKey ed25519.PublicKey) Ed25519Address {
+	return blake2b.Sum256(pubKey[:])
+}
+
+// Matches tells whether the address is the BLAKE2b-256 hash of the given public key.
+// The comparison is done in constant time.
+func (edAddr *Ed25519Address) Matches(pubKey ed25519.PublicKey) bool {
+	pubKeyAddr := AddressFromEd25519PubKey(pubKey)
+	return subtle.ConstantTimeCompare(pubKeyAddr[:], edAddr[:]) == 1
+}
+
+// Bech32 encodes the address in its Bech32 form using the given human-readable part.
+func (edAddr *Ed25519Address) Bech32(hrp string) (string, error) {
+	addrBytes, err := edAddr.Serialize(DeSeriModeNoValidation)
+	if err != nil {
+		return "", err
+	}
+	return bech32Encode(hrp, addrBytes)
+}
+
 func (edAddr *Ed25519Address) Deserialize(data []byte, deSeriMode DeSerializationMode) (int, error) {
 	if deSeriMode.HasMode(DeSeriModePerformValidation) {
 		if err := checkMinByteLength(Ed25519AddressSerializedBytesSize, len(data)); err != nil {
@@ -77,13 +176,305 @@ func (edAddr *Ed25519Address) Deserialize(data []byte, deSeriMode DeSerializatio
 			return 0, fmt.Errorf("unable to deserialize Ed25519 address: %w", err)
 		}
 	}
-	copy(edAddr[:], data[SmallTypeDenotationByteSize:])
-	return Ed25519AddressSerializedBytesSize, nil
+	return NewDeserializer(data).
+		Skip(SmallTypeDenotationByteSize, "Ed25519 address type").
+		ReadBytes(edAddr[:], "Ed25519 address").
+		Done()
+}
+
+func (edAddr *Ed25519Address) ValidateBytes(data []byte, deSeriMode DeSerializationMode) (int, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := checkTypeByte(data, AddressEd25519); err != nil {
+			return 0, fmt.Errorf("invalid Ed25519 address bytes: %w", err)
+		}
+	}
+	return NewDeserializer(data).
+		Skip(Ed25519AddressSerializedBytesSize, "Ed25519 address").
+		Done()
 }
 
 func (edAddr *Ed25519Address) Serialize(deSeriMode DeSerializationMode) (data []byte, err error) {
 	var b [Ed25519AddressSerializedBytesSize]byte
-	b[0] = AddressEd25519
-	copy(b[SmallTypeDenotationByteSize:], edAddr[:])
+	if _, err := edAddr.SerializeInto(b[:], deSeriMode); err != nil {
+		return nil, err
+	}
 	return b[:], nil
 }
+
+// WriteTo writes the serialized Ed25519Address to w without allocating, implementing io.WriterTo.
+func (edAddr *Ed25519Address) WriteTo(w io.Writer) (int64, error) {
+	var b [Ed25519AddressSerializedBytesSize]byte
+	return writeFixedSizeTo(w, edAddr, b[:])
+}
+
+func (edAddr *Ed25519Address) SerializeInto(buf []byte, deSeriMode DeSerializationMode) (int, error) {
+	if err := checkSerializationBufferSize(Ed25519AddressSerializedBytesSize, len(buf)); err != nil {
+		return 0, err
+	}
+	buf[0] = AddressEd25519
+	copy(buf[SmallTypeDenotationByteSize:], edAddr[:])
+	return Ed25519AddressSerializedBytesSize, nil
+}
+
+func (edAddr *Ed25519Address) SerializedSize() int {
+	return Ed25519AddressSerializedBytesSize
+}
+
+// Defines an alias address, which is the ID of the alias controlling it.
+type AliasAddress [AliasAddressBytesLength]byte
+
+// Equal tells whether the given address is the same address.
+func (aliasAddr *AliasAddress) Equal(other *AliasAddress) bool {
+	return other != nil && *aliasAddr == *other
+}
+
+// Bech32 encodes the address in its Bech32 form using the given human-readable part.
+func (aliasAddr *AliasAddress) Bech32(hrp string) (string, error) {
+	addrBytes, err := aliasAddr.Serialize(DeSeriModeNoValidation)
+	if err != nil {
+		return "", err
+	}
+	return bech32Encode(hrp, addrBytes)
+}
+
+func (aliasAddr *AliasAddress) Deserialize(data []byte, deSeriMode DeSerializationMode) (int, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := checkMinByteLength(AliasAddressSerializedBytesSize, len(data)); err != nil {
+			return 0, fmt.Errorf("invalid alias address bytes: %w", err)
+		}
+		if err := checkTypeByte(data, AddressAlias); err != nil {
+			return 0, fmt.Errorf("unable to deserialize alias address: %w", err)
+		}
+	}
+	return NewDeserializer(data).
+		Skip(SmallTypeDenotationByteSize, "alias address type").
+		ReadBytes(aliasAddr[:], "alias address").
+		AbortIf(func() error {
+			if deSeriMode.HasMode(DeSeriModePerformValidation) {
+				return aliasAddr.validate()
+			}
+			return nil
+		}).
+		Done()
+}
+
+func (aliasAddr *AliasAddress) ValidateBytes(data []byte, deSeriMode DeSerializationMode) (int, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := checkTypeByte(data, AddressAlias); err != nil {
+			return 0, fmt.Errorf("invalid alias address bytes: %w", err)
+		}
+	}
+	var aliasID AliasAddress
+	return NewDeserializer(data).
+		Skip(SmallTypeDenotationByteSize, "alias address type").
+		ReadBytes(aliasID[:], "alias address").
+		AbortIf(func() error {
+			if deSeriMode.HasMode(DeSeriModePerformValidation) {
+				return aliasID.validate()
+			}
+			return nil
+		}).
+		Done()
+}
+
+func (aliasAddr *AliasAddress) Serialize(deSeriMode DeSerializationMode) (data []byte, err error) {
+	var b [AliasAddressSerializedBytesSize]byte
+	if _, err := aliasAddr.SerializeInto(b[:], deSeriMode); err != nil {
+		return nil, err
+	}
+	return b[:], nil
+}
+
+// WriteTo writes the serialized AliasAddress to w without allocating, implementing io.WriterTo.
+func (aliasAddr *AliasAddress) WriteTo(w io.Writer) (int64, error) {
+	var b [AliasAddressSerializedBytesSize]byte
+	return writeFixedSizeTo(w, aliasAddr, b[:])
+}
+
+func (aliasAddr *AliasAddress) SerializeInto(buf []byte, deSeriMode DeSerializationMode) (int, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := aliasAddr.validate(); err != nil {
+			return 0, err
+		}
+	}
+	if err := checkSerializationBufferSize(AliasAddressSerializedBytesSize, len(buf)); err != nil {
+		return 0, err
+	}
+	buf[0] = AddressAlias
+	copy(buf[SmallTypeDenotationByteSize:], aliasAddr[:])
+	return AliasAddressSerializedBytesSize, nil
+}
+
+func (aliasAddr *AliasAddress) SerializedSize() int {
+	return AliasAddressSerializedBytesSize
+}
+
+// validate checks that the alias address is not the zeroed alias ID.
+func (aliasAddr *AliasAddress) validate() error {
+	if *aliasAddr == (AliasAddress{}) {
+		return fmt.Errorf("%w: alias address is the zeroed alias ID", ErrZeroAddress)
+	}
+	return nil
+}
+
+// Defines an NFT address, which is the ID of the NFT controlling it.
+type NFTAddress [NFTAddressBytesLength]byte
+
+// Equal tells whether the given address is the same address.
+func (nftAddr *NFTAddress) Equal(other *NFTAddress) bool {
+	return other != nil && *nftAddr == *other
+}
+
+// Bech32 encodes the address in its Bech32 form using the given human-readable part.
+func (nftAddr *NFTAddress) Bech32(hrp string) (string, error) {
+	addrBytes, err := nftAddr.Serialize(DeSeriModeNoValidation)
+	if err != nil {
+		return "", err
+	}
+	return bech32Encode(hrp, addrBytes)
+}
+
+func (nftAddr *NFTAddress) Deserialize(data []byte, deSeriMode DeSerializationMode) (int, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := checkMinByteLength(NFTAddressSerializedBytesSize, len(data)); err != nil {
+			return 0, fmt.Errorf("invalid NFT address bytes: %w", err)
+		}
+		if err := checkTypeByte(data, AddressNFT); err != nil {
+			return 0, fmt.Errorf("unable to deserialize NFT address: %w", err)
+		}
+	}
+	return NewDeserializer(data).
+		Skip(SmallTypeDenotationByteSize, "NFT address type").
+		ReadBytes(nftAddr[:], "NFT address").
+		AbortIf(func() error {
+			if deSeriMode.HasMode(DeSeriModePerformValidation) {
+				return nftAddr.validate()
+			}
+			return nil
+		}).
+		Done()
+}
+
+func (nftAddr *NFTAddress) ValidateBytes(data []byte, deSeriMode DeSerializationMode) (int, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := checkTypeByte(data, AddressNFT); err != nil {
+			return 0, fmt.Errorf("invalid NFT address bytes: %w", err)
+		}
+	}
+	var nftID NFTAddress
+	return NewDeserializer(data).
+		Skip(SmallTypeDenotationByteSize, "NFT address type").
+		ReadBytes(nftID[:], "NFT address").
+		AbortIf(func() error {
+			if deSeriMode.HasMode(DeSeriModePerformValidation) {
+				return nftID.validate()
+			}
+			return nil
+		}).
+		Done()
+}
+
+func (nftAddr *NFTAddress) Serialize(deSeriMode DeSerializationMode) (data []byte, err error) {
+	var b [NFTAddressSerializedBytesSize]byte
+	if _, err := nftAddr.SerializeInto(b[:], deSeriMode); err != nil {
+		return nil, err
+	}
+	return b[:], nil
+}
+
+// WriteTo writes the serialized NFTAddress to w without allocating, implementing io.WriterTo.
+func (nftAddr *NFTAddress) WriteTo(w io.Writer) (int64, error) {
+	var b [NFTAddressSerializedBytesSize]byte
+	return writeFixedSizeTo(w, nftAddr, b[:])
+}
+
+func (nftAddr *NFTAddress) SerializeInto(buf []byte, deSeriMode DeSerializationMode) (int, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := nftAddr.validate(); err != nil {
+			return 0, err
+		}
+	}
+	if err := checkSerializationBufferSize(NFTAddressSerializedBytesSize, len(buf)); err != nil {
+		return 0, err
+	}
+	buf[0] = AddressNFT
+	copy(buf[SmallTypeDenotationByteSize:], nftAddr[:])
+	return NFTAddressSerializedBytesSize, nil
+}
+
+func (nftAddr *NFTAddress) SerializedSize() int {
+	return NFTAddressSerializedBytesSize
+}
+
+// validate checks that the NFT address is not the zeroed NFT ID.
+func (nftAddr *NFTAddress) validate() error {
+	if *nftAddr == (NFTAddress{}) {
+		return fmt.Errorf("%w: NFT address is the zeroed NFT ID", ErrZeroAddress)
+	}
+	return nil
+}
+
+type jsonAddress struct {
+	Type    uint32 `json:"type"`
+	Address string `json:"address"`
+}
+
+func (wotsAddr *WOTSAddress) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonAddress{Type: uint32(AddressWOTS), Address: hex.EncodeToString(wotsAddr[:])})
+}
+
+func (wotsAddr *WOTSAddress) UnmarshalJSON(data []byte) error {
+	jAddr := &jsonAddress{}
+	if err := json.Unmarshal(data, jAddr); err != nil {
+		return err
+	}
+	if err := checkJSONType(jAddr.Type, uint32(AddressWOTS), "WOTS address"); err != nil {
+		return err
+	}
+	return decodeHexInto(wotsAddr[:], jAddr.Address, "WOTS address")
+}
+
+func (edAddr *Ed25519Address) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonAddress{Type: uint32(AddressEd25519), Address: hex.EncodeToString(edAddr[:])})
+}
+
+func (edAddr *Ed25519Address) UnmarshalJSON(data []byte) error {
+	jAddr := &jsonAddress{}
+	if err := json.Unmarshal(data, jAddr); err != nil {
+		return err
+	}
+	if err := checkJSONType(jAddr.Type, uint32(AddressEd25519), "Ed25519 address"); err != nil {
+		return err
+	}
+	return decodeHexInto(edAddr[:], jAddr.Address, "Ed25519 address")
+}
+
+func (aliasAddr *AliasAddress) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonAddress{Type: uint32(AddressAlias), Address: hex.EncodeToString(aliasAddr[:])})
+}
+
+func (aliasAddr *AliasAddress) UnmarshalJSON(data []byte) error {
+	jAddr := &jsonAddress{}
+	if err := json.Unmarshal(data, jAddr); err != nil {
+		return err
+	}
+	if err := checkJSONType(jAddr.Type, uint32(AddressAlias), "alias address"); err != nil {
+		return err
+	}
+	return decodeHexInto(aliasAddr[:], jAddr.Address, "alias address")
+}
+
+func (nftAddr *NFTAddress) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonAddress{Type: uint32(AddressNFT), Address: hex.EncodeToString(nftAddr[:])})
+}
+
+func (nftAddr *NFTAddress) UnmarshalJSON(data []byte) error {
+	jAddr := &jsonAddress{}
+	if err := json.Unmarshal(data, jAddr); err != nil {
+		return err
+	}
+	if err := checkJSONType(jAddr.Type, uint32(AddressNFT), "NFT address"); err != nil {
+		return err
+	}
+	return decodeHexInto(nftAddr[:], jAddr.Address, "NFT address")
+}
@@ -0,0 +1,30 @@
+//go:build go1.18
+// +build go1.18
+
+package iota_test
+
+import (
+	"testing"
+
+	"github.com/luca-moser/iota"
+)
+
+func FuzzWOTSAddressDeserialize(f *testing.F) {
+	_, data := randWOTSAddr()
+	fuzzDeserialize(f, func() iota.Serializable { return &iota.WOTSAddress{} }, data)
+}
+
+func FuzzEd25519AddressDeserialize(f *testing.F) {
+	_, data := randEd25519Addr()
+	fuzzDeserialize(f, func() iota.Serializable { return &iota.Ed25519Address{} }, data)
+}
+
+func FuzzAliasAddressDeserialize(f *testing.F) {
+	_, data := randAliasAddr()
+	fuzzDeserialize(f, func() iota.Serializable { return &iota.AliasAddress{} }, data)
+}
+
+func FuzzNFTAddressDeserialize(f *testing.F) {
+	_, data := randNFTAddr()
+	fuzzDeserialize(f, func() iota.Serializable { return &iota.NFTAddress{} }, data)
+}
@@ -1,13 +1,32 @@
 package iota_test
 
 import (
+	"crypto/ed25519"
 	"errors"
 	"testing"
 
 	"github.com/luca-moser/iota"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
 )
 
+func TestAddressSelector(t *testing.T) {
+	_, err := iota.AddressSelector(100)
+	assert.True(t, errors.Is(err, iota.ErrUnknownAddrType))
+
+	// an unknown type byte fails the generic deserialization of an output's address
+	_, edAddrData := randEd25519Addr()
+	edAddrData[0] = 100
+	_, _, err = iota.DeserializeObject(edAddrData, iota.DeSeriModePerformValidation, iota.TypeDenotationByte, iota.AddressSelector)
+	assert.True(t, errors.Is(err, iota.ErrUnknownAddrType))
+
+	for _, ty := range []iota.AddressType{iota.AddressWOTS, iota.AddressEd25519, iota.AddressAlias, iota.AddressNFT} {
+		addr, err := iota.AddressSelector(uint32(ty))
+		assert.NoError(t, err)
+		assert.NotNil(t, addr)
+	}
+}
+
 func TestWOTSAddress_Deserialize(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -43,6 +62,7 @@ func TestWOTSAddress_Deserialize(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, len(tt.wotsAddrData), bytesRead)
 			assert.Equal(t, tt.wotsAddrData[iota.SmallTypeDenotationByteSize:], wotsAddr[:])
+			AssertTruncationSafe(t, tt.wotsAddrData, func() iota.Serializable { return &iota.WOTSAddress{} })
 		})
 	}
 }
@@ -102,6 +122,7 @@ func TestEd25519Address_Deserialize(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, len(tt.edAddrData), bytesRead)
 			assert.Equal(t, tt.edAddrData[iota.SmallTypeDenotationByteSize:], edAddr[:])
+			AssertTruncationSafe(t, tt.edAddrData, func() iota.Serializable { return &iota.Ed25519Address{} })
 		})
 	}
 }
@@ -125,3 +146,130 @@ func TestEd25519Address_Serialize(t *testing.T) {
 		})
 	}
 }
+
+func TestAliasAddress_Deserialize(t *testing.T) {
+	originAliasAddr, originData := randAliasAddr()
+	tests := []struct {
+		name          string
+		aliasAddrData []byte
+		target        *iota.AliasAddress
+		err           error
+	}{
+		{"ok", originData, originAliasAddr, nil},
+		{"not enough bytes", originData[:iota.AliasAddressSerializedBytesSize-1], nil, iota.ErrDeserializationNotEnoughData},
+		{"zeroed alias ID", func() []byte {
+			var b [iota.AliasAddressSerializedBytesSize]byte
+			b[0] = iota.AddressAlias
+			return b[:]
+		}(), nil, iota.ErrZeroAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, bytesRead, err := iota.DeserializeObject(tt.aliasAddrData, iota.DeSeriModePerformValidation, iota.TypeDenotationByte, iota.AddressSelector)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "expected %v, got %v", tt.err, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, len(tt.aliasAddrData), bytesRead)
+			assert.EqualValues(t, tt.target, addr)
+			AssertTruncationSafe(t, tt.aliasAddrData, func() iota.Serializable { return &iota.AliasAddress{} })
+		})
+	}
+}
+
+func TestAliasAddress_Serialize(t *testing.T) {
+	originAliasAddr, originData := randAliasAddr()
+	tests := []struct {
+		name   string
+		source *iota.AliasAddress
+		target []byte
+		err    error
+	}{
+		{"ok", originAliasAddr, originData, nil},
+		{"zeroed alias ID", &iota.AliasAddress{}, nil, iota.ErrZeroAddress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aliasData, err := tt.source.Serialize(iota.DeSeriModePerformValidation)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "expected %v, got %v", tt.err, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.target, aliasData)
+		})
+	}
+
+	// without validation the zeroed alias ID serializes
+	_, err := (&iota.AliasAddress{}).Serialize(iota.DeSeriModeNoValidation)
+	assert.NoError(t, err)
+}
+
+func TestNFTAddress_RoundTrip(t *testing.T) {
+	originNFTAddr, originData := randNFTAddr()
+
+	nftData, err := originNFTAddr.Serialize(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.Equal(t, originData, nftData)
+
+	addr, bytesRead, err := iota.DeserializeObject(nftData, iota.DeSeriModePerformValidation, iota.TypeDenotationByte, iota.AddressSelector)
+	assert.NoError(t, err)
+	assert.Equal(t, iota.NFTAddressSerializedBytesSize, bytesRead)
+	assert.EqualValues(t, originNFTAddr, addr)
+	AssertTruncationSafe(t, nftData, func() iota.Serializable { return &iota.NFTAddress{} })
+
+	_, err = (&iota.NFTAddress{}).Serialize(iota.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, iota.ErrZeroAddress))
+	var zeroData [iota.NFTAddressSerializedBytesSize]byte
+	zeroData[0] = iota.AddressNFT
+	_, err = (&iota.NFTAddress{}).Deserialize(zeroData[:], iota.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, iota.ErrZeroAddress))
+}
+
+func TestAddressFromEd25519PubKey(t *testing.T) {
+	pubKey, _, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	addr := iota.AddressFromEd25519PubKey(pubKey)
+	assert.Len(t, addr, iota.Ed25519AddressBytesLength)
+	assert.Equal(t, blake2b.Sum256(pubKey), [iota.Ed25519AddressBytesLength]byte(addr))
+	assert.Equal(t, addr, iota.AddressFromEd25519PubKey(pubKey))
+
+	otherPubKey, _, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	assert.NotEqual(t, addr, iota.AddressFromEd25519PubKey(otherPubKey))
+}
+
+func TestEd25519Address_Matches(t *testing.T) {
+	pubKey, _, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	otherPubKey, _, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	addr := iota.AddressFromEd25519PubKey(pubKey)
+	assert.True(t, addr.Matches(pubKey))
+	assert.False(t, addr.Matches(otherPubKey))
+}
+
+func TestAddress_Equal(t *testing.T) {
+	edAddr, _ := randEd25519Addr()
+	edAddrCopy := *edAddr
+	assert.True(t, edAddr.Equal(&edAddrCopy))
+	edAddrCopy[0]++
+	assert.False(t, edAddr.Equal(&edAddrCopy))
+	assert.False(t, edAddr.Equal(nil))
+
+	wotsAddr, _ := randWOTSAddr()
+	wotsAddrCopy := *wotsAddr
+	assert.True(t, wotsAddr.Equal(&wotsAddrCopy))
+
+	aliasAddr, _ := randAliasAddr()
+	otherAliasAddr, _ := randAliasAddr()
+	assert.False(t, aliasAddr.Equal(otherAliasAddr))
+
+	nftAddr, _ := randNFTAddr()
+	nftAddrCopy := *nftAddr
+	assert.True(t, nftAddr.Equal(&nftAddrCopy))
+}
@@ -0,0 +1,208 @@
+package iota
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// The max length of the human-readable part.
+	bech32MaxHRPLength = 83
+	// The max length of a Bech32 address string. BIP-173 limits strings to 90 characters, which a WOTS address
+	// exceeds together with the network prefixes, so the limit fits the longest address with the longest human-readable part.
+	bech32MaxLength = bech32MaxHRPLength + 1 + (WOTSAddressSerializedBytesSize*8+4)/5 + bech32ChecksumLength
+	// The separator between the human-readable part and the data part.
+	bech32Separator = '1'
+	// The amount of characters making up the checksum.
+	bech32ChecksumLength = 6
+	// The character set used to encode the data part.
+	bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+// NetworkPrefix is the human-readable part of the Bech32 addresses of a network.
+type NetworkPrefix string
+
+const (
+	// The network prefix of the mainnet.
+	PrefixMainnet NetworkPrefix = "iota"
+	// The network prefix of the testnet.
+	PrefixTestnet NetworkPrefix = "atoi"
+)
+
+var (
+	ErrInvalidBech32          = errors.New("invalid bech32 string")
+	ErrBech32ChecksumMismatch = errors.New("bech32 checksum mismatch")
+	ErrInvalidNetworkPrefix   = errors.New("bech32 string has an unexpected network prefix")
+
+	bech32Generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+)
+
+// bech32Polymod computes the BCH checksum over the given 5 bit values.
+func bech32Polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < len(bech32Generator); i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= bech32Generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+// bech32HRPExpand expands the human-readable part for the checksum computation.
+func bech32HRPExpand(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+	return expanded
+}
+
+// bech32ConvertBits regroups the given values of fromBits size into values of toBits size.
+func bech32ConvertBits(data []byte, fromBits uint, toBits uint, pad bool) ([]byte, error) {
+	var acc uint32
+	var bits uint
+	maxV := uint32(1)<<toBits - 1
+	converted := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+	for _, v := range data {
+		if uint32(v)>>fromBits != 0 {
+			return nil, fmt.Errorf("%w: value %d exceeds %d bits", ErrInvalidBech32, v, fromBits)
+		}
+		acc = acc<<fromBits | uint32(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			converted = append(converted, byte(acc>>bits&maxV))
+		}
+	}
+	switch {
+	case pad && bits > 0:
+		converted = append(converted, byte(acc<<(toBits-bits)&maxV))
+	case !pad && (bits >= fromBits || acc<<(toBits-bits)&maxV != 0):
+		return nil, fmt.Errorf("%w: invalid padding", ErrInvalidBech32)
+	}
+	return converted, nil
+}
+
+// bech32Encode encodes the given data bytes with the given human-readable part into a Bech32 string.
+func bech32Encode(hrp string, data []byte) (string, error) {
+	if len(hrp) == 0 || len(hrp) > bech32MaxHRPLength {
+		return "", fmt.Errorf("%w: human-readable part must be between 1 and %d characters long but is %d", ErrInvalidBech32, bech32MaxHRPLength, len(hrp))
+	}
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", fmt.Errorf("%w: invalid human-readable part character %q", ErrInvalidBech32, hrp[i])
+		}
+	}
+	if strings.ToLower(hrp) != hrp {
+		return "", fmt.Errorf("%w: human-readable part must be lower case", ErrInvalidBech32)
+	}
+
+	values, err := bech32ConvertBits(data, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	if length := len(hrp) + 1 + len(values) + bech32ChecksumLength; length > bech32MaxLength {
+		return "", fmt.Errorf("%w: length %d exceeds max of %d", ErrInvalidBech32, length, bech32MaxLength)
+	}
+
+	polymod := bech32Polymod(append(append(bech32HRPExpand(hrp), values...), make([]byte, bech32ChecksumLength)...)) ^ 1
+	for i := 0; i < bech32ChecksumLength; i++ {
+		values = append(values, byte(polymod>>uint(5*(bech32ChecksumLength-1-i))&31))
+	}
+
+	var b strings.Builder
+	b.WriteString(hrp)
+	b.WriteByte(bech32Separator)
+	for _, v := range values {
+		b.WriteByte(bech32Charset[v])
+	}
+	return b.String(), nil
+}
+
+// bech32Decode decodes the given Bech32 string into its human-readable part and its data bytes.
+func bech32Decode(s string) (string, []byte, error) {
+	if len(s) > bech32MaxLength {
+		return "", nil, fmt.Errorf("%w: length %d exceeds max of %d", ErrInvalidBech32, len(s), bech32MaxLength)
+	}
+
+	lower := strings.ToLower(s)
+	if lower != s && strings.ToUpper(s) != s {
+		return "", nil, fmt.Errorf("%w: mixed case", ErrInvalidBech32)
+	}
+
+	sepIndex := strings.LastIndexByte(lower, bech32Separator)
+	if sepIndex < 1 || sepIndex+bech32ChecksumLength+1 > len(lower) {
+		return "", nil, fmt.Errorf("%w: invalid separator position", ErrInvalidBech32)
+	}
+
+	hrp := lower[:sepIndex]
+	if len(hrp) > bech32MaxHRPLength {
+		return "", nil, fmt.Errorf("%w: human-readable part must be max %d characters long but is %d", ErrInvalidBech32, bech32MaxHRPLength, len(hrp))
+	}
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, fmt.Errorf("%w: invalid human-readable part character %q", ErrInvalidBech32, hrp[i])
+		}
+	}
+
+	dataPart := lower[sepIndex+1:]
+	values := make([]byte, len(dataPart))
+	for i := 0; i < len(dataPart); i++ {
+		v := strings.IndexByte(bech32Charset, dataPart[i])
+		if v == -1 {
+			return "", nil, fmt.Errorf("%w: invalid data character %q", ErrInvalidBech32, dataPart[i])
+		}
+		values[i] = byte(v)
+	}
+
+	if bech32Polymod(append(bech32HRPExpand(hrp), values...)) != 1 {
+		return "", nil, ErrBech32ChecksumMismatch
+	}
+
+	data, err := bech32ConvertBits(values[:len(values)-bech32ChecksumLength], 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+	return hrp, data, nil
+}
+
+// ParseBech32 decodes the given Bech32 string and returns the address it encodes together with its human-readable part.
+// An error is returned if the checksum doesn't match or the encoded address is not valid.
+func ParseBech32(s string) (Serializable, string, error) {
+	hrp, data, err := bech32Decode(s)
+	if err != nil {
+		return nil, "", err
+	}
+
+	addr, addrBytesRead, err := DeserializeObject(data, DeSeriModePerformValidation, TypeDenotationByte, AddressSelector)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to deserialize address within bech32 string: %w", err)
+	}
+	if addrBytesRead != len(data) {
+		return nil, "", fmt.Errorf("%w: bech32 string contains %d bytes after the address", ErrDeserializationNotAllConsumed, len(data)-addrBytesRead)
+	}
+	return addr, hrp, nil
+}
+
+// ParseBech32WithPrefix works like ParseBech32 but additionally returns an error wrapping ErrInvalidNetworkPrefix
+// if the human-readable part isn't the expected network prefix. Use it to reject addresses of other networks.
+func ParseBech32WithPrefix(s string, expectedPrefix NetworkPrefix) (Serializable, error) {
+	addr, hrp, err := ParseBech32(s)
+	if err != nil {
+		return nil, err
+	}
+	if NetworkPrefix(hrp) != expectedPrefix {
+		return nil, fmt.Errorf("%w: expected %q but got %q", ErrInvalidNetworkPrefix, expectedPrefix, hrp)
+	}
+	return addr, nil
+}
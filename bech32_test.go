@@ -0,0 +1,222 @@
+package iota_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/luca-moser/iota"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEd25519Address_Bech32RoundTrip(t *testing.T) {
+	for _, hrp := range []string{"iota", "atoi"} {
+		t.Run(hrp, func(t *testing.T) {
+			edAddr, _ := randEd25519Addr()
+			bech32Addr, err := edAddr.Bech32(hrp)
+			assert.NoError(t, err)
+
+			addr, parsedHRP, err := iota.ParseBech32(bech32Addr)
+			assert.NoError(t, err)
+			assert.Equal(t, hrp, parsedHRP)
+			assert.EqualValues(t, edAddr, addr)
+		})
+	}
+}
+
+func TestAliasAddress_Bech32RoundTrip(t *testing.T) {
+	aliasAddr, _ := randAliasAddr()
+	bech32Addr, err := aliasAddr.Bech32("iota")
+	assert.NoError(t, err)
+
+	addr, hrp, err := iota.ParseBech32(bech32Addr)
+	assert.NoError(t, err)
+	assert.Equal(t, "iota", hrp)
+	assert.EqualValues(t, aliasAddr, addr)
+
+	// the zeroed alias ID encodes but doesn't parse
+	zeroBech32Addr, err := (&iota.AliasAddress{}).Bech32("iota")
+	assert.NoError(t, err)
+	_, _, err = iota.ParseBech32(zeroBech32Addr)
+	assert.True(t, errors.Is(err, iota.ErrZeroAddress))
+}
+
+func TestWOTSAddress_Bech32RoundTrip(t *testing.T) {
+	for _, prefix := range []iota.NetworkPrefix{iota.PrefixMainnet, iota.PrefixTestnet} {
+		t.Run(string(prefix), func(t *testing.T) {
+			wotsAddr, _ := randWOTSAddr()
+			bech32Addr, err := wotsAddr.Bech32(string(prefix))
+			assert.NoError(t, err)
+
+			addr, err := iota.ParseBech32WithPrefix(bech32Addr, prefix)
+			assert.NoError(t, err)
+			assert.IsType(t, &iota.WOTSAddress{}, addr)
+			assert.EqualValues(t, wotsAddr, addr)
+		})
+	}
+}
+
+func TestParseBech32_AddressTypes(t *testing.T) {
+	edAddr, _ := randEd25519Addr()
+	aliasAddr, _ := randAliasAddr()
+	nftAddr, _ := randNFTAddr()
+	// alias and NFT addresses share the same length, so only their type byte tells them apart
+	copy(nftAddr[:], aliasAddr[:])
+
+	tests := []struct {
+		name   string
+		addr   interface{ Bech32(string) (string, error) }
+		target iota.Serializable
+	}{
+		{"Ed25519 address", edAddr, edAddr},
+		{"alias address", aliasAddr, aliasAddr},
+		{"NFT address", nftAddr, nftAddr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bech32Addr, err := tt.addr.Bech32("iota")
+			assert.NoError(t, err)
+			addr, _, err := iota.ParseBech32(bech32Addr)
+			assert.NoError(t, err)
+			assert.IsType(t, tt.target, addr)
+			assert.EqualValues(t, tt.target, addr)
+		})
+	}
+}
+
+func TestParseBech32(t *testing.T) {
+	// BLAKE2b-256 hash of the Ed25519 public key from the RFC-0020 example, encoded with
+	// this package's Ed25519 address type byte; the published encoding is checked in TestBech32Vectors
+	var edAddr iota.Ed25519Address
+	_, err := hex.Decode(edAddr[:], []byte("efdc112efe262b304bcf379b26c31bad029f616ee3ec4aa6345a366e4c9e43a3"))
+	assert.NoError(t, err)
+
+	type test struct {
+		name   string
+		bech32 string
+		target iota.Serializable
+		hrp    string
+		err    error
+	}
+	tests := []test{
+		{"ok", "iota1q8hacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6x6h3a83", &edAddr, "iota", nil},
+		{"ok upper case", "IOTA1Q8HACYFWLCNZKVZTEUMEKFKRRWKS98MPDM37CJ4XX3DRVMJVNEP6X6H3A83", &edAddr, "iota", nil},
+		{"checksum mismatch", "iota1q8hacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6x6h3a84", nil, "", iota.ErrBech32ChecksumMismatch},
+		{"unknown address type", "iota1vnhacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6x6qased", nil, "", iota.ErrUnknownAddrType},
+		{"truncated address", "iota1q8hacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnepspxrmlm", nil, "", iota.ErrDeserializationNotEnoughData},
+		// invalid strings from BIP-173
+		{"no separator", "pzry9x0s0muk", nil, "", iota.ErrInvalidBech32},
+		{"empty hrp", "1pzry9x0s0muk", nil, "", iota.ErrInvalidBech32},
+		{"invalid data character", "x1b4n0q5v", nil, "", iota.ErrInvalidBech32},
+		{"too short checksum", "li1dgmt3", nil, "", iota.ErrInvalidBech32},
+		{"checksum over upper case hrp", "A1G7SGD8", nil, "", iota.ErrBech32ChecksumMismatch},
+		{"mixed case", "iota1Q8hacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6x6h3a83", nil, "", iota.ErrInvalidBech32},
+		{"too long human-readable part", strings.Repeat("a", 84) + "1qqqqqq", nil, "", iota.ErrInvalidBech32},
+		{"too long", "iota1" + strings.Repeat("q", 200), nil, "", iota.ErrInvalidBech32},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, hrp, err := iota.ParseBech32(tt.bech32)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "unexpected error %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.hrp, hrp)
+			assert.EqualValues(t, tt.target, addr)
+		})
+	}
+}
+
+func TestBech32Vectors(t *testing.T) {
+	type test struct {
+		name   string
+		bech32 string
+		hrp    string
+		data   string
+	}
+	tests := []test{
+		// valid strings from BIP-173
+		{"empty data", "a12uel5l", "a", ""},
+		{"max length human-readable part", "an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1tt5tgs", "an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio", ""},
+		{"full charset", "abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", "abcdef", "00443214c74254b635cf84653a56d7c675be77df"},
+		{"separator as human-readable part", "11" + strings.Repeat("q", 82) + "c8247j", "1", strings.Repeat("00", 51)},
+		{"words", "split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w", "split", "c5f38b70305f519bf66d85fb6cf03058f3dde463ecd7918f2dc743918f2d"},
+		{"symbol human-readable part", "?1ezyfcl", "?", ""},
+		// Ed25519 addresses from RFC-0020, which number the Ed25519 address type 0 instead of 1
+		{"mainnet Ed25519 address", "iota1qrhacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6xqgyzyx", "iota", "00efdc112efe262b304bcf379b26c31bad029f616ee3ec4aa6345a366e4c9e43a3"},
+		{"testnet Ed25519 address", "atoi1qrhacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6x8x4r7t", "atoi", "00efdc112efe262b304bcf379b26c31bad029f616ee3ec4aa6345a366e4c9e43a3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := hex.DecodeString(tt.data)
+			assert.NoError(t, err)
+
+			hrp, decoded, err := iota.Bech32Decode(tt.bech32)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.hrp, hrp)
+			assert.Equal(t, data, append([]byte{}, decoded...))
+
+			encoded, err := iota.Bech32Encode(tt.hrp, data)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.bech32, encoded)
+		})
+	}
+
+	// the upper case form decodes to the same human-readable part and data
+	hrp, decoded, err := iota.Bech32Decode("A12UEL5L")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", hrp)
+	assert.Empty(t, decoded)
+}
+
+func TestParseBech32WithPrefix(t *testing.T) {
+	edAddr, _ := randEd25519Addr()
+	mainnetAddr, err := edAddr.Bech32(string(iota.PrefixMainnet))
+	assert.NoError(t, err)
+	testnetAddr, err := edAddr.Bech32(string(iota.PrefixTestnet))
+	assert.NoError(t, err)
+
+	type test struct {
+		name   string
+		bech32 string
+		prefix iota.NetworkPrefix
+		err    error
+	}
+	tests := []test{
+		{"mainnet", mainnetAddr, iota.PrefixMainnet, nil},
+		{"testnet", testnetAddr, iota.PrefixTestnet, nil},
+		{"testnet address on mainnet", testnetAddr, iota.PrefixMainnet, iota.ErrInvalidNetworkPrefix},
+		{"mainnet address on testnet", mainnetAddr, iota.PrefixTestnet, iota.ErrInvalidNetworkPrefix},
+		{"invalid string", mainnetAddr[:len(mainnetAddr)-1], iota.PrefixMainnet, iota.ErrBech32ChecksumMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := iota.ParseBech32WithPrefix(tt.bech32, tt.prefix)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "unexpected error %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, edAddr, addr)
+		})
+	}
+}
+
+func TestEd25519Address_Bech32(t *testing.T) {
+	var edAddr iota.Ed25519Address
+	_, err := hex.Decode(edAddr[:], []byte("efdc112efe262b304bcf379b26c31bad029f616ee3ec4aa6345a366e4c9e43a3"))
+	assert.NoError(t, err)
+
+	bech32Addr, err := edAddr.Bech32("iota")
+	assert.NoError(t, err)
+	assert.Equal(t, "iota1q8hacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6x6h3a83", bech32Addr)
+
+	bech32Addr, err = edAddr.Bech32("atoi")
+	assert.NoError(t, err)
+	assert.Equal(t, "atoi1q8hacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6xaequau", bech32Addr)
+
+	_, err = edAddr.Bech32("IOTA")
+	assert.True(t, errors.Is(err, iota.ErrInvalidBech32))
+}
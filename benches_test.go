@@ -59,6 +59,25 @@ func BenchmarkSerializeWithoutValidationOneIOSigTxPayload(b *testing.B) {
 	}
 }
 
+func BenchmarkSerializeIntoWithoutValidationOneIOSigTxPayload(b *testing.B) {
+	sigTxPayload := oneInputOutputSignedTransactionPayload()
+	buf := make([]byte, 1024)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sigTxPayload.SerializeInto(buf, iota.DeSeriModeNoValidation)
+	}
+}
+
+func BenchmarkSerializeWithoutValidationOneIOSigTxPayloadAllocs(b *testing.B) {
+	sigTxPayload := oneInputOutputSignedTransactionPayload()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sigTxPayload.Serialize(iota.DeSeriModeNoValidation)
+	}
+}
+
 func BenchmarkSignEd25519OneIOUnsignedTx(b *testing.B) {
 	sigTxPayload := oneInputOutputSignedTransactionPayload()
 	b.ResetTimer()
@@ -93,3 +112,97 @@ func BenchmarkVerifyEd25519OneIOUnsignedTx(b *testing.B) {
 		ed25519.Verify(pubKey, unsigTxData, sig)
 	}
 }
+
+func BenchmarkValidateBytesWithValidationOneIOSigTxPayload(b *testing.B) {
+	data, err := oneInputOutputSignedTransactionPayload().Serialize(iota.DeSeriModeNoValidation)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		iota.ValidateBytes(data, iota.DeSeriModePerformValidation, iota.PayloadSelector)
+	}
+}
+
+func BenchmarkValidateBytesWithoutValidationOneIOSigTxPayload(b *testing.B) {
+	data, err := oneInputOutputSignedTransactionPayload().Serialize(iota.DeSeriModeNoValidation)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		iota.ValidateBytes(data, iota.DeSeriModeNoValidation, iota.PayloadSelector)
+	}
+}
+
+func BenchmarkSerializeWithoutValidationOneIOSigTxPayloadParallel(b *testing.B) {
+	sigTxPayload := oneInputOutputSignedTransactionPayload()
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			sigTxPayload.Serialize(iota.DeSeriModeNoValidation)
+		}
+	})
+}
+
+func BenchmarkDeserializeArrayOfObjects(b *testing.B) {
+	data := randUTXOInputsArray(10)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		iota.DeserializeArrayOfObjects(data, iota.DeSeriModeNoValidation, iota.TypeDenotationByte, iota.InputSelector, nil)
+	}
+}
+
+func BenchmarkDeserializeArrayOfObjectsInto(b *testing.B) {
+	data := randUTXOInputsArray(10)
+	var dst iota.Serializables
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst, _, _ = iota.DeserializeArrayOfObjectsInto(dst[:0], data, iota.DeSeriModeNoValidation, iota.TypeDenotationByte, iota.InputSelector, nil)
+	}
+}
+
+func randUTXOInputsArray(count int) []byte {
+	inputs := make(iota.Serializables, count)
+	for i := range inputs {
+		inputs[i], _ = randUTXOInput()
+	}
+	data, err := iota.NewSerializer().WriteSliceOfObjects(inputs, iota.DeSeriModeNoValidation, nil, "inputs").Serialize()
+	must(err)
+	return data
+}
+
+func benchOutputs() iota.Serializables {
+	outputs := make(iota.Serializables, iota.MaxOutputsCount)
+	for i := range outputs {
+		outputs[i], _ = randSigLockedSingleOutput(iota.AddressEd25519)
+	}
+	return outputs
+}
+
+func BenchmarkSerializeConcatOutputs(b *testing.B) {
+	outputs := benchOutputs()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		outputs.SerializeConcat(iota.DeSeriModeNoValidation)
+	}
+}
+
+func BenchmarkSerializeOutputsOneByOne(b *testing.B) {
+	outputs := benchOutputs()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var data []byte
+		for _, output := range outputs {
+			outputData, _ := output.Serialize(iota.DeSeriModeNoValidation)
+			data = append(data, outputData...)
+		}
+	}
+}
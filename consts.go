@@ -25,16 +25,19 @@ const (
 	SmallTypeDenotationByteSize = OneByte
 	// The size of the version of a message.
 	MessageVersionByteSize = SmallTypeDenotationByteSize
+	// The size of the format version of an unsigned transaction.
+	UnsignedTransactionFormatVersionByteSize = OneByte
 	// // The byte size of struct array lengths.
 	StructArrayLengthByteSize = UInt16ByteSize
 	// // The byte size of byte array lengths.
 	ByteArrayLengthByteSize = UInt32ByteSize
 	// The size of the payload length denoting bytes.
+	// An absent payload is encoded as a zero payload length with no payload bytes following it.
 	PayloadLengthByteSize = UInt32ByteSize
 	// The minimum size of a payload (together with its length denotation).
 	MinPayloadByteSize = UInt32ByteSize + OneByte
 	// The IOTA token supply.
-	TokenSupply = 2_779_530_283_277_761
+	TokenSupply uint64 = 2_779_530_283_277_761
 )
 
 type TypeDenotationType byte
@@ -42,4 +45,6 @@ type TypeDenotationType byte
 const (
 	TypeDenotationUint32 TypeDenotationType = iota
 	TypeDenotationByte
+	// Denotes that the objects don't carry a type denotation. The selector is then called with type 0.
+	TypeDenotationNone
 )
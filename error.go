@@ -4,12 +4,14 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"strings"
 )
 
 var (
 	ErrInvalidBytes                  = errors.New("invalid bytes")
 	ErrDeserializationTypeMismatch   = errors.New("data type is invalid for deserialization")
 	ErrUnknownPayloadType            = errors.New("unknown payload type")
+	ErrPayloadTooLarge               = errors.New("payload exceeds the max payload length")
 	ErrUnknownAddrType               = errors.New("unknown address type")
 	ErrUnknownInputType              = errors.New("unknown input type")
 	ErrUnknownOutputType             = errors.New("unknown output type")
@@ -18,8 +20,87 @@ var (
 	ErrUnknownSignatureType          = errors.New("unknown signature type")
 	ErrDeserializationNotEnoughData  = errors.New("not enough data for deserialization")
 	ErrDeserializationNotAllConsumed = errors.New("not all data has been consumed but should have been")
+	ErrUnsupportedFormatVersion      = errors.New("unsupported format version")
+	ErrSerializationBufferTooSmall   = errors.New("buffer is too small for the serialized form")
+	ErrNonCanonicalSerialization     = errors.New("serialized form doesn't deserialize back into the same object")
+	ErrInvalidHex                    = errors.New("invalid hex string")
+	ErrSelectorReturnedNil           = errors.New("selector returned neither an object nor an error")
+	ErrNonDeterministicSerialization = errors.New("serialization is not deterministic")
+	ErrObjectTooLarge                = errors.New("object exceeds the max object length")
+	// ErrTrailingBytes is returned if data holds further bytes after the object which should span all of it.
+	ErrTrailingBytes = fmt.Errorf("%w: data has trailing bytes", ErrDeserializationNotAllConsumed)
 )
 
+// ValidationErrors aggregates the errors of a validation which collects multiple issues instead of stopping at the first one.
+type ValidationErrors []error
+
+func (v ValidationErrors) Error() string {
+	msgs := make([]string, len(v))
+	for i, err := range v {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the aggregated errors.
+func (v ValidationErrors) Unwrap() []error {
+	return v
+}
+
+// Is reports whether any of the aggregated errors matches target.
+// It allows errors.Is to traverse the aggregated errors on Go versions which don't support multi-error wrapping.
+func (v ValidationErrors) Is(target error) bool {
+	for _, err := range v {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// DeserializationError is returned by DeserializeObject and DeserializeArrayOfObjects and denotes the offset
+// within their data at which deserialization failed. Errors of nested objects carry their offset within the outermost data.
+type DeserializationError struct {
+	// The offset of the object, array or type denotation which failed to deserialize.
+	Offset int
+	// The underlying error.
+	Err error
+}
+
+func (e *DeserializationError) Error() string {
+	return fmt.Sprintf("at offset %d: %v", e.Offset, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *DeserializationError) Unwrap() error {
+	return e.Err
+}
+
+// deserializationErrorAt returns err as a *DeserializationError at the given offset, prefixing it with errCtx if it's not empty.
+// If err already is a *DeserializationError, its offset is relative to the given one.
+func deserializationErrorAt(offset int, err error, errCtx string) error {
+	if deErr, ok := err.(*DeserializationError); ok {
+		offset += deErr.Offset
+		err = deErr.Err
+	}
+	if errCtx != "" {
+		err = fmt.Errorf("%s: %w", errCtx, err)
+	}
+	return &DeserializationError{Offset: offset, Err: err}
+}
+
+// shiftDeserializationError works like deserializationErrorAt but leaves errors which aren't a *DeserializationError
+// without an offset, as they don't stem from a failed object.
+func shiftDeserializationError(offset int, err error, errCtx string) error {
+	if _, ok := err.(*DeserializationError); ok {
+		return deserializationErrorAt(offset, err, errCtx)
+	}
+	if errCtx != "" {
+		return fmt.Errorf("%s: %w", errCtx, err)
+	}
+	return err
+}
+
 func checkType(data []byte, shouldType uint32) error {
 	actualType := binary.LittleEndian.Uint32(data)
 	if actualType != shouldType {
@@ -38,6 +119,23 @@ func checkTypeByte(data []byte, shouldType byte) error {
 	return nil
 }
 
+func checkSerializedTypeByte(seriBytes []byte, serSel SerializableSelectorFunc) error {
+	if len(seriBytes) == 0 {
+		return fmt.Errorf("%w: serialized form is empty", ErrInvalidBytes)
+	}
+	if _, err := serSel(uint32(seriBytes[0])); err != nil {
+		return fmt.Errorf("%w: serialized form starts with an invalid type byte: %v", ErrInvalidBytes, err)
+	}
+	return nil
+}
+
+func checkSerializationBufferSize(needed int, length int) error {
+	if length < needed {
+		return fmt.Errorf("%w: %d bytes are needed but the buffer is only %d bytes long", ErrSerializationBufferTooSmall, needed, length)
+	}
+	return nil
+}
+
 func checkExactByteLength(exact int, length int) error {
 	if length != exact {
 		return fmt.Errorf("%w: data must be at exact %d bytes long but is %d", ErrInvalidBytes, exact, length)
@@ -0,0 +1,39 @@
+package iota_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/luca-moser/iota"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationErrors(t *testing.T) {
+	var errs iota.ValidationErrors
+	addrUniqueValidator := iota.OutputsAddrUniqueValidator()
+	depositAmountValidator := iota.OutputsDepositAmountValidator()
+
+	edAddr, _ := randEd25519Addr()
+	outputs := iota.Serializables{
+		&iota.SigLockedSingleOutput{Address: edAddr, Amount: 0},
+		&iota.SigLockedSingleOutput{Address: edAddr, Amount: 1337},
+	}
+	for i, output := range outputs {
+		dep := output.(*iota.SigLockedSingleOutput)
+		if err := addrUniqueValidator(i, dep); err != nil {
+			errs = append(errs, err)
+		}
+		if err := depositAmountValidator(i, dep); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	assert.Len(t, errs, 2)
+	var err error = errs
+	assert.True(t, errors.Is(err, iota.ErrOutputAddrNotUnique))
+	assert.True(t, errors.Is(err, iota.ErrDepositAmountMustBeGreaterThanZero))
+	assert.False(t, errors.Is(err, iota.ErrOutputsSumExceedsTotalSupply))
+	assert.True(t, errors.Is(fmt.Errorf("wrapped: %w", err), iota.ErrOutputAddrNotUnique))
+	assert.Equal(t, errs[0].Error()+"; "+errs[1].Error(), err.Error())
+}
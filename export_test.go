@@ -0,0 +1,7 @@
+package iota
+
+// exported for the tests of package iota_test
+var (
+	Bech32Encode = bech32Encode
+	Bech32Decode = bech32Decode
+)
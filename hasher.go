@@ -0,0 +1,33 @@
+package iota
+
+import (
+	"golang.org/x/crypto/blake2b"
+)
+
+// HashLength is the length of the digests computed by a Hasher.
+const HashLength = 32
+
+// Hasher computes the digests from which IDs are derived.
+type Hasher interface {
+	// Hash returns the digest of the concatenation of the given byte slices.
+	Hash(data ...[]byte) [HashLength]byte
+}
+
+// blake2b256Hasher is a Hasher computing BLAKE2b-256 digests.
+type blake2b256Hasher struct{}
+
+func (blake2b256Hasher) Hash(data ...[]byte) [HashLength]byte {
+	// blake2b.New256 only errors on keys which are too long
+	h, _ := blake2b.New256(nil)
+	for _, d := range data {
+		h.Write(d)
+	}
+	var hash [HashLength]byte
+	h.Sum(hash[:0])
+	return hash
+}
+
+// DefaultHasher returns the BLAKE2b-256 Hasher used by the protocol.
+func DefaultHasher() Hasher {
+	return blake2b256Hasher{}
+}
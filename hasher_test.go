@@ -0,0 +1,41 @@
+package iota_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/luca-moser/iota"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+// stubHasher records the data it is asked to hash and returns a fixed digest.
+type stubHasher struct {
+	hashed [][]byte
+}
+
+func (s *stubHasher) Hash(data ...[]byte) [iota.HashLength]byte {
+	s.hashed = append(s.hashed, bytes.Join(data, nil))
+	return [iota.HashLength]byte{1, 3, 3, 7}
+}
+
+func TestDefaultHasher(t *testing.T) {
+	data := randBytes(100)
+	assert.Equal(t, blake2b.Sum256(data), iota.DefaultHasher().Hash(data[:40], data[40:]))
+}
+
+func TestIDWithHasher(t *testing.T) {
+	msg, msgData := randMessage(iota.IndexationPayloadID)
+	hasher := &stubHasher{}
+	msgID, err := msg.IDWithHasher(hasher)
+	assert.NoError(t, err)
+	assert.Equal(t, [iota.MessageIDLength]byte{1, 3, 3, 7}, msgID)
+	assert.Equal(t, [][]byte{append(append([]byte{}, iota.MessageIDDomain...), msgData...)}, hasher.hashed)
+
+	unTx, unTxData := randUnsignedTransaction()
+	hasher = &stubHasher{}
+	txID, err := unTx.IDWithHasher(hasher)
+	assert.NoError(t, err)
+	assert.Equal(t, [iota.TransactionIDLength]byte{1, 3, 3, 7}, txID)
+	assert.Equal(t, [][]byte{append(append([]byte{}, iota.TransactionIDDomain...), unTxData...)}, hasher.hashed)
+}
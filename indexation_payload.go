@@ -1,15 +1,25 @@
 package iota
 
 import (
-	"bytes"
-	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 )
 
 const (
 	IndexationPayloadID uint32 = 2
 	// type bytes + index prefix + one char + data length
 	IndexationPayloadMinSize = TypeDenotationByteSize + UInt16ByteSize + OneByte + UInt32ByteSize
+	// The min length of an indexation payload's index.
+	IndexationPayloadIndexMinLength = 1
+	// The max length of an indexation payload's index.
+	IndexationPayloadIndexMaxLength = 64
+)
+
+var (
+	ErrIndexationPayloadIndexLengthInvalid = errors.New(fmt.Sprintf("indexation payload index must be between %d and %d bytes long", IndexationPayloadIndexMinLength, IndexationPayloadIndexMaxLength))
 )
 
 // IndexationPayload is a payload which holds an index and associated data.
@@ -28,58 +38,87 @@ func (u *IndexationPayload) Deserialize(data []byte, deSeriMode DeSerializationM
 		}
 	}
 
-	data = data[TypeDenotationByteSize:]
-	index, indexBytesRead, err := ReadStringFromBytes(data)
-	if err != nil {
-		return 0, err
-	}
-	u.Index = index
-	data = data[indexBytesRead:]
+	return NewDeserializer(data).
+		Skip(TypeDenotationByteSize, "indexation payload type").
+		ReadString(&u.Index, "indexation payload index").
+		AbortIf(func() error {
+			if deSeriMode.HasMode(DeSeriModePerformValidation) {
+				return indexationPayloadIndexLengthValid(len(u.Index))
+			}
+			return nil
+		}).
+		ReadVariableBytes(&u.Data, "indexation payload data").
+		Done()
+}
+
+func (u *IndexationPayload) ValidateBytes(data []byte, deSeriMode DeSerializationMode) (int, error) {
+	var indexLength uint16
+	d := NewDeserializer(data).
+		Skip(TypeDenotationByteSize, "indexation payload type").
+		ReadNum(&indexLength, "indexation payload index length").
+		AbortIf(func() error {
+			if deSeriMode.HasMode(DeSeriModePerformValidation) {
+				return indexationPayloadIndexLengthValid(int(indexLength))
+			}
+			return nil
+		})
+
+	return d.
+		Skip(int(indexLength), "indexation payload index").
+		SkipVariableBytes("indexation payload data").
+		Done()
+}
 
-	// read data length
-	dataLength := binary.LittleEndian.Uint32(data)
+func (u *IndexationPayload) Serialize(deSeriMode DeSerializationMode) ([]byte, error) {
 	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := indexationPayloadIndexLengthValid(len(u.Index)); err != nil {
+			return nil, err
+		}
 		// TODO: check data length
 	}
 
-	data = data[ByteArrayLengthByteSize:]
-	bytesAvailable := uint32(len(data)) - dataLength
-	if bytesAvailable < 0 {
-		return 0, fmt.Errorf("%w: indexation payload length denotes too many bytes (%d bytes)", ErrDeserializationNotEnoughData, dataLength)
-	}
-
-	u.Data = make([]byte, dataLength)
-	copy(u.Data, data[:dataLength])
+	return NewSerializer().
+		WriteNum(IndexationPayloadID, "indexation payload type").
+		WriteString(u.Index, "indexation payload index").
+		WriteVariableBytes(u.Data, "indexation payload data").
+		Serialize()
+}
 
-	return TypeDenotationByteSize + indexBytesRead + ByteArrayLengthByteSize + int(dataLength), nil
+// WriteTo serializes the IndexationPayload with WriteToMode and writes it to w, implementing io.WriterTo.
+func (u *IndexationPayload) WriteTo(w io.Writer) (int64, error) {
+	return writeSerializableTo(w, u)
 }
 
-func (u *IndexationPayload) Serialize(deSeriMode DeSerializationMode) ([]byte, error) {
-	if deSeriMode.HasMode(DeSeriModePerformValidation) {
-		// TODO: check data length
+// indexationPayloadIndexLengthValid checks whether the given index length is within the allowed bounds.
+func indexationPayloadIndexLengthValid(length int) error {
+	if length < IndexationPayloadIndexMinLength || length > IndexationPayloadIndexMaxLength {
+		return fmt.Errorf("%w: index is %d bytes long", ErrIndexationPayloadIndexLengthInvalid, length)
 	}
+	return nil
+}
 
-	var b bytes.Buffer
-	if err := binary.Write(&b, binary.LittleEndian, IndexationPayloadID); err != nil {
-		return nil, err
-	}
+type jsonIndexationPayload struct {
+	Type  uint32 `json:"type"`
+	Index string `json:"index"`
+	Data  string `json:"data"`
+}
 
-	strLen := uint16(len(u.Index))
-	if err := binary.Write(&b, binary.LittleEndian, strLen); err != nil {
-		return nil, err
-	}
+func (u *IndexationPayload) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonIndexationPayload{Type: IndexationPayloadID, Index: u.Index, Data: hex.EncodeToString(u.Data)})
+}
 
-	if _, err := b.Write([]byte(u.Index)); err != nil {
-		return nil, err
+func (u *IndexationPayload) UnmarshalJSON(data []byte) error {
+	jPayload := &jsonIndexationPayload{}
+	if err := json.Unmarshal(data, jPayload); err != nil {
+		return err
 	}
-
-	if err := binary.Write(&b, binary.LittleEndian, uint32(len(u.Data))); err != nil {
-		return nil, err
+	if err := checkJSONType(jPayload.Type, IndexationPayloadID, "indexation payload"); err != nil {
+		return err
 	}
-
-	if _, err := b.Write(u.Data); err != nil {
-		return nil, err
+	indexData, err := hex.DecodeString(jPayload.Data)
+	if err != nil {
+		return fmt.Errorf("%w: unable to decode indexation payload data from hex: %v", ErrInvalidBytes, err)
 	}
-
-	return b.Bytes(), nil
+	u.Index, u.Data = jPayload.Index, indexData
+	return nil
 }
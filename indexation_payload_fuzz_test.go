@@ -0,0 +1,15 @@
+//go:build go1.18
+// +build go1.18
+
+package iota_test
+
+import (
+	"testing"
+
+	"github.com/luca-moser/iota"
+)
+
+func FuzzIndexationPayloadDeserialize(f *testing.F) {
+	_, data := randIndexationPayload()
+	fuzzDeserialize(f, func() iota.Serializable { return &iota.IndexationPayload{} }, data)
+}
@@ -2,6 +2,7 @@ package iota_test
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/luca-moser/iota"
@@ -33,6 +34,7 @@ func TestIndexationPayload_Deserialize(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, len(tt.source), bytesRead)
 			assert.EqualValues(t, tt.target, indexationPayload)
+			AssertTruncationSafe(t, tt.source, func() iota.Serializable { return &iota.IndexationPayload{} })
 		})
 	}
 }
@@ -57,3 +59,42 @@ func TestIndexationPayload_Serialize(t *testing.T) {
 		})
 	}
 }
+
+func TestIndexationPayload_IndexLength(t *testing.T) {
+	type test struct {
+		name  string
+		index string
+		err   error
+	}
+	tests := []test{
+		{"min length", strings.Repeat("a", iota.IndexationPayloadIndexMinLength), nil},
+		{"max length", strings.Repeat("a", iota.IndexationPayloadIndexMaxLength), nil},
+		{"empty", "", iota.ErrIndexationPayloadIndexLengthInvalid},
+		{"exceeds max length", strings.Repeat("a", iota.IndexationPayloadIndexMaxLength+1), iota.ErrIndexationPayloadIndexLengthInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexationPayload := &iota.IndexationPayload{Index: tt.index, Data: randBytes(10)}
+			_, err := indexationPayload.Serialize(iota.DeSeriModePerformValidation)
+			data, seriErr := indexationPayload.Serialize(iota.DeSeriModeNoValidation)
+			assert.NoError(t, seriErr)
+			_, deSeriErr := (&iota.IndexationPayload{}).Deserialize(data, iota.DeSeriModePerformValidation)
+			_, validateErr := iota.ValidateBytes(data, iota.DeSeriModePerformValidation, iota.PayloadSelector)
+
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+				assert.True(t, errors.Is(deSeriErr, tt.err))
+				assert.True(t, errors.Is(validateErr, tt.err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.NoError(t, deSeriErr)
+			assert.NoError(t, validateErr)
+
+			deserialized := &iota.IndexationPayload{}
+			_, err = deserialized.Deserialize(data, iota.DeSeriModePerformValidation)
+			assert.NoError(t, err)
+			assert.EqualValues(t, indexationPayload, deserialized)
+		})
+	}
+}
@@ -2,9 +2,11 @@ package iota
 
 import (
 	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"strings"
+	"io"
 )
 
 // Defines the type of inputs.
@@ -13,16 +15,31 @@ type InputType = byte
 const (
 	// A type of input which references an unspent transaction output.
 	InputUTXO InputType = iota
+	// A type of input which references the milestone which generated the treasury output to spend.
+	InputTreasury
 
 	RefUTXOIndexMin = 0
 	RefUTXOIndexMax = 126
 
 	// input type + tx id + index
 	UTXOInputSize = SmallTypeDenotationByteSize + TransactionIDLength + UInt16ByteSize
+	// tx id + index
+	UTXOInputIDLength = TransactionIDLength + UInt16ByteSize
+
+	// The length of a milestone ID referenced by a treasury input.
+	MilestoneIDLength = MilestoneHashLength
+	// input type + milestone id
+	TreasuryInputSize = SmallTypeDenotationByteSize + MilestoneIDLength
 )
 
 var (
-	ErrRefUTXOIndexInvalid = errors.New(fmt.Sprintf("the referenced UTXO index must be between %d and %d (inclusive)", RefUTXOIndexMin, RefUTXOIndexMax))
+	ErrRefUTXOIndexInvalid    = errors.New("the referenced UTXO index is out of bounds")
+	ErrInputZeroTransactionID = errors.New("input must not reference the all zero transaction ID")
+	ErrMixedTreasuryInputs    = errors.New("treasury inputs can not be mixed with other inputs")
+
+	// The max output index a UTXO input can reference. Applications on networks with different
+	// parameters can adjust it, it is read every time an input is validated.
+	MaxOutputIndex uint16 = RefUTXOIndexMax
 )
 
 // InputSelector implements SerializableSelectorFunc for input types.
@@ -31,6 +48,8 @@ func InputSelector(inputType uint32) (Serializable, error) {
 	switch byte(inputType) {
 	case InputUTXO:
 		seri = &UTXOInput{}
+	case InputTreasury:
+		seri = &TreasuryInput{}
 	default:
 		return nil, fmt.Errorf("%w: type %d", ErrUnknownInputType, inputType)
 	}
@@ -45,6 +64,49 @@ type UTXOInput struct {
 	TransactionOutputIndex uint16 `json:"transaction_output_index"`
 }
 
+// NewUTXOInput creates a UTXOInput referencing the output at the given index of the transaction with the given ID.
+// An error wrapping ErrInvalidBytes is returned if the transaction ID is not exactly TransactionIDLength bytes long.
+func NewUTXOInput(txID []byte, outputIndex uint16) (*UTXOInput, error) {
+	if err := checkExactByteLength(TransactionIDLength, len(txID)); err != nil {
+		return nil, fmt.Errorf("invalid UTXO input transaction ID: %w", err)
+	}
+	input := &UTXOInput{TransactionOutputIndex: outputIndex}
+	copy(input.TransactionID[:], txID)
+	return input, nil
+}
+
+// UTXOInputID is the ID of an output referenced by a UTXO input: the transaction ID followed by the output index.
+type UTXOInputID [UTXOInputIDLength]byte
+
+// UTXOInputIDFromHex parses the given hex string into a UTXOInputID.
+// An error wrapping ErrInvalidHex is returned if it isn't valid hex
+// and one wrapping ErrInvalidBytes if it doesn't decode to exactly UTXOInputIDLength bytes.
+func UTXOInputIDFromHex(hexStr string) (UTXOInputID, error) {
+	var id UTXOInputID
+	data, err := hex.DecodeString(hexStr)
+	if err != nil {
+		return id, fmt.Errorf("%w: %v", ErrInvalidHex, err)
+	}
+	if err := checkExactByteLength(UTXOInputIDLength, len(data)); err != nil {
+		return id, fmt.Errorf("invalid UTXO input ID: %w", err)
+	}
+	copy(id[:], data)
+	return id, nil
+}
+
+// ToHex returns the hex representation of the UTXOInputID.
+func (id UTXOInputID) ToHex() string {
+	return hex.EncodeToString(id[:])
+}
+
+// ID returns the ID of the referenced output which is made up of the transaction ID and the output index.
+func (u *UTXOInput) ID() UTXOInputID {
+	var id UTXOInputID
+	copy(id[:TransactionIDLength], u.TransactionID[:])
+	binary.LittleEndian.PutUint16(id[TransactionIDLength:], u.TransactionOutputIndex)
+	return id
+}
+
 func (u *UTXOInput) Deserialize(data []byte, deSeriMode DeSerializationMode) (int, error) {
 	if deSeriMode.HasMode(DeSeriModePerformValidation) {
 		if err := checkMinByteLength(UTXOInputSize, len(data)); err != nil {
@@ -55,53 +117,129 @@ func (u *UTXOInput) Deserialize(data []byte, deSeriMode DeSerializationMode) (in
 		}
 	}
 
-	data = data[SmallTypeDenotationByteSize:]
+	return NewDeserializer(data).
+		Skip(SmallTypeDenotationByteSize, "UTXO input type").
+		ReadBytes(u.TransactionID[:], "UTXO input transaction ID").
+		ReadNum(&u.TransactionOutputIndex, "UTXO input transaction output index").
+		AbortIf(func() error {
+			if deSeriMode.HasMode(DeSeriModePerformValidation) {
+				return utxoInputRefBoundsValidator(-1, u)
+			}
+			return nil
+		}).
+		Done()
+}
+
+func (u *UTXOInput) ValidateBytes(data []byte, deSeriMode DeSerializationMode) (int, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := checkTypeByte(data, InputUTXO); err != nil {
+			return 0, fmt.Errorf("invalid UTXO input bytes: %w", err)
+		}
+	}
+	var index uint16
+	return NewDeserializer(data).
+		Skip(SmallTypeDenotationByteSize+TransactionIDLength, "UTXO input transaction ID").
+		ReadNum(&index, "UTXO input transaction output index").
+		AbortIf(func() error {
+			if deSeriMode.HasMode(DeSeriModePerformValidation) {
+				return utxoInputRefBoundsValidator(-1, &UTXOInput{TransactionOutputIndex: index})
+			}
+			return nil
+		}).
+		Done()
+}
 
-	// read transaction id
-	copy(u.TransactionID[:], data[:TransactionIDLength])
-	data = data[TransactionIDLength:]
+func (u *UTXOInput) Serialize(deSeriMode DeSerializationMode) (data []byte, err error) {
+	var b [UTXOInputSize]byte
+	if _, err := u.SerializeInto(b[:], deSeriMode); err != nil {
+		return nil, err
+	}
+	return b[:], nil
+}
 
-	// output index
-	u.TransactionOutputIndex = binary.LittleEndian.Uint16(data)
+// WriteTo writes the serialized UTXOInput to w without allocating, implementing io.WriterTo.
+func (u *UTXOInput) WriteTo(w io.Writer) (int64, error) {
+	var b [UTXOInputSize]byte
+	return writeFixedSizeTo(w, u, b[:])
+}
 
+func (u *UTXOInput) SerializeInto(buf []byte, deSeriMode DeSerializationMode) (int, error) {
 	if deSeriMode.HasMode(DeSeriModePerformValidation) {
 		if err := utxoInputRefBoundsValidator(-1, u); err != nil {
 			return 0, err
 		}
 	}
+	if err := checkSerializationBufferSize(UTXOInputSize, len(buf)); err != nil {
+		return 0, err
+	}
 
+	buf[0] = InputUTXO
+	copy(buf[SmallTypeDenotationByteSize:], u.TransactionID[:])
+	binary.LittleEndian.PutUint16(buf[UTXOInputSize-UInt16ByteSize:], u.TransactionOutputIndex)
 	return UTXOInputSize, nil
 }
 
-func (u *UTXOInput) Serialize(deSeriMode DeSerializationMode) (data []byte, err error) {
+func (u *UTXOInput) SerializedSize() int {
+	return UTXOInputSize
+}
+
+// TreasuryInput references the milestone which generated the treasury output to spend.
+type TreasuryInput [MilestoneIDLength]byte
+
+func (ti *TreasuryInput) Deserialize(data []byte, deSeriMode DeSerializationMode) (int, error) {
 	if deSeriMode.HasMode(DeSeriModePerformValidation) {
-		if err := utxoInputRefBoundsValidator(-1, u); err != nil {
-			return nil, err
+		if err := checkMinByteLength(TreasuryInputSize, len(data)); err != nil {
+			return 0, fmt.Errorf("invalid treasury input bytes: %w", err)
+		}
+		if err := checkTypeByte(data, InputTreasury); err != nil {
+			return 0, fmt.Errorf("unable to deserialize treasury input: %w", err)
 		}
 	}
 
-	var b [UTXOInputSize]byte
-	b[0] = InputUTXO
-	copy(b[SmallTypeDenotationByteSize:], u.TransactionID[:])
-	binary.LittleEndian.PutUint16(b[UTXOInputSize-UInt16ByteSize:], u.TransactionOutputIndex)
+	return NewDeserializer(data).
+		Skip(SmallTypeDenotationByteSize, "treasury input type").
+		ReadBytes(ti[:], "treasury input milestone ID").
+		Done()
+}
+
+func (ti *TreasuryInput) Serialize(deSeriMode DeSerializationMode) ([]byte, error) {
+	var b [TreasuryInputSize]byte
+	if _, err := ti.SerializeInto(b[:], deSeriMode); err != nil {
+		return nil, err
+	}
 	return b[:], nil
 }
 
+// WriteTo writes the serialized TreasuryInput to w without allocating, implementing io.WriterTo.
+func (ti *TreasuryInput) WriteTo(w io.Writer) (int64, error) {
+	var b [TreasuryInputSize]byte
+	return writeFixedSizeTo(w, ti, b[:])
+}
+
+func (ti *TreasuryInput) SerializeInto(buf []byte, deSeriMode DeSerializationMode) (int, error) {
+	if err := checkSerializationBufferSize(TreasuryInputSize, len(buf)); err != nil {
+		return 0, err
+	}
+	buf[0] = InputTreasury
+	copy(buf[SmallTypeDenotationByteSize:], ti[:])
+	return TreasuryInputSize, nil
+}
+
+func (ti *TreasuryInput) SerializedSize() int {
+	return TreasuryInputSize
+}
+
 // InputsValidatorFunc which given the index of an input and the input itself, runs validations and returns an error if any should fail.
+// ValidateInputs calls it once per input in the order of the inputs, so a validator may keep state over the calls,
+// like InputsUTXORefsUniqueValidator does. Such validators must be created anew for every set of inputs.
 type InputsValidatorFunc func(index int, input *UTXOInput) error
 
 // InputsUTXORefsUniqueValidator returns a validator which checks that every input has a unique UTXO ref.
 func InputsUTXORefsUniqueValidator() InputsValidatorFunc {
 	set := map[string]int{}
 	return func(index int, input *UTXOInput) error {
-		var b strings.Builder
-		if _, err := b.Write(input.TransactionID[:]); err != nil {
-			return err
-		}
-		if err := binary.Write(&b, binary.LittleEndian, input.TransactionOutputIndex); err != nil {
-			return err
-		}
-		k := b.String()
+		id := input.ID()
+		k := string(id[:])
 		if j, has := set[k]; has {
 			return fmt.Errorf("%w: input %d and %d share the same UTXO ref", ErrInputUTXORefsNotUnique, j, index)
 		}
@@ -110,11 +248,21 @@ func InputsUTXORefsUniqueValidator() InputsValidatorFunc {
 	}
 }
 
-// InputsUTXORefIndexBoundsValidator returns a validator which checks that the UTXO ref index is within bounds.
+// InputsUTXORefIndexBoundsValidator returns a validator which checks that the UTXO ref index is within RefUTXOIndexMin and MaxOutputIndex.
 func InputsUTXORefIndexBoundsValidator() InputsValidatorFunc {
 	return func(index int, input *UTXOInput) error {
-		if input.TransactionOutputIndex < RefUTXOIndexMin || input.TransactionOutputIndex > RefUTXOIndexMax {
-			return fmt.Errorf("%w: input %d", ErrRefUTXOIndexInvalid, index)
+		if input.TransactionOutputIndex < RefUTXOIndexMin || input.TransactionOutputIndex > MaxOutputIndex {
+			return fmt.Errorf("%w: input %d references index %d but must be between %d and %d (inclusive)", ErrRefUTXOIndexInvalid, index, input.TransactionOutputIndex, RefUTXOIndexMin, MaxOutputIndex)
+		}
+		return nil
+	}
+}
+
+// InputsNonZeroTxIDValidator returns a validator which checks that no input references the all zero transaction ID.
+func InputsNonZeroTxIDValidator() InputsValidatorFunc {
+	return func(index int, input *UTXOInput) error {
+		if input.TransactionID == [TransactionIDLength]byte{} {
+			return fmt.Errorf("%w: input %d", ErrInputZeroTransactionID, index)
 		}
 		return nil
 	}
@@ -123,8 +271,13 @@ func InputsUTXORefIndexBoundsValidator() InputsValidatorFunc {
 var utxoInputRefBoundsValidator = InputsUTXORefIndexBoundsValidator()
 
 // ValidateInputs validates the inputs by running them against the given InputsValidatorFunc.
+// For every input all funcs are run before the next input is validated. The first error is returned.
+// Treasury inputs can only be spent as the sole input of a TreasuryTransaction and are therefore rejected.
 func ValidateInputs(inputs Serializables, funcs ...InputsValidatorFunc) error {
 	for i, input := range inputs {
+		if _, isTreasuryInput := input.(*TreasuryInput); isTreasuryInput {
+			return fmt.Errorf("%w: input %d is a treasury input", ErrMixedTreasuryInputs, i)
+		}
 		dep, ok := input.(*UTXOInput)
 		if !ok {
 			return fmt.Errorf("%w: can only validate on UTXO inputs", ErrUnknownInputType)
@@ -137,3 +290,52 @@ func ValidateInputs(inputs Serializables, funcs ...InputsValidatorFunc) error {
 	}
 	return nil
 }
+
+type jsonUTXOInput struct {
+	Type                   uint32 `json:"type"`
+	TransactionID          string `json:"transaction_id"`
+	TransactionOutputIndex uint16 `json:"transaction_output_index"`
+}
+
+func (u *UTXOInput) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonUTXOInput{
+		Type:                   uint32(InputUTXO),
+		TransactionID:          hex.EncodeToString(u.TransactionID[:]),
+		TransactionOutputIndex: u.TransactionOutputIndex,
+	})
+}
+
+func (u *UTXOInput) UnmarshalJSON(data []byte) error {
+	jInput := &jsonUTXOInput{}
+	if err := json.Unmarshal(data, jInput); err != nil {
+		return err
+	}
+	if err := checkJSONType(jInput.Type, uint32(InputUTXO), "UTXO input"); err != nil {
+		return err
+	}
+	if err := decodeHexInto(u.TransactionID[:], jInput.TransactionID, "UTXO input transaction ID"); err != nil {
+		return err
+	}
+	u.TransactionOutputIndex = jInput.TransactionOutputIndex
+	return nil
+}
+
+type jsonTreasuryInput struct {
+	Type        uint32 `json:"type"`
+	MilestoneID string `json:"milestone_id"`
+}
+
+func (ti *TreasuryInput) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonTreasuryInput{Type: uint32(InputTreasury), MilestoneID: hex.EncodeToString(ti[:])})
+}
+
+func (ti *TreasuryInput) UnmarshalJSON(data []byte) error {
+	jInput := &jsonTreasuryInput{}
+	if err := json.Unmarshal(data, jInput); err != nil {
+		return err
+	}
+	if err := checkJSONType(jInput.Type, uint32(InputTreasury), "treasury input"); err != nil {
+		return err
+	}
+	return decodeHexInto(ti[:], jInput.MilestoneID, "treasury input milestone ID")
+}
@@ -0,0 +1,21 @@
+//go:build go1.18
+// +build go1.18
+
+package iota_test
+
+import (
+	"testing"
+
+	"github.com/luca-moser/iota"
+)
+
+func FuzzUTXOInputDeserialize(f *testing.F) {
+	_, data := randUTXOInput()
+	fuzzDeserialize(f, func() iota.Serializable { return &iota.UTXOInput{} }, data)
+}
+
+func FuzzTreasuryInputDeserialize(f *testing.F) {
+	data, err := (&iota.TreasuryInput{}).Serialize(iota.DeSeriModePerformValidation)
+	must(err)
+	fuzzDeserialize(f, func() iota.Serializable { return &iota.TreasuryInput{} }, data)
+}
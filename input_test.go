@@ -1,7 +1,9 @@
 package iota_test
 
 import (
+	"encoding/binary"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/luca-moser/iota"
@@ -34,10 +36,27 @@ func TestUTXOInput_Deserialize(t *testing.T) {
 			}
 			assert.Equal(t, len(tt.data), bytesRead)
 			assert.EqualValues(t, tt.target, u)
+			AssertTruncationSafe(t, tt.data, func() iota.Serializable { return &iota.UTXOInput{} })
 		})
 	}
 }
 
+func TestTreasuryInput_RoundTrip(t *testing.T) {
+	origin := &iota.TreasuryInput{}
+	copy(origin[:], randBytes(iota.MilestoneIDLength))
+
+	data, err := origin.Serialize(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.Len(t, data, iota.TreasuryInputSize)
+	assert.Equal(t, iota.InputTreasury, data[0])
+
+	input, bytesRead, err := iota.DeserializeObject(data, iota.DeSeriModePerformValidation, iota.TypeDenotationByte, iota.InputSelector)
+	assert.NoError(t, err)
+	assert.Equal(t, len(data), bytesRead)
+	assert.EqualValues(t, origin, input)
+	AssertTruncationSafe(t, data, func() iota.Serializable { return &iota.TreasuryInput{} })
+}
+
 func TestUTXOInput_Serialize(t *testing.T) {
 	randUTXOInput, randSerializedUTXOInput := randUTXOInput()
 	tests := []struct {
@@ -114,6 +133,28 @@ func TestInputsValidatorFunc(t *testing.T) {
 				},
 			}, funcs: []iota.InputsValidatorFunc{iota.InputsUTXORefIndexBoundsValidator()}}, true,
 		},
+		{
+			"ok non zero tx ID",
+			args{inputs: []iota.Serializable{
+				&iota.UTXOInput{
+					TransactionID:          randTxHash(),
+					TransactionOutputIndex: 0,
+				},
+			}, funcs: []iota.InputsValidatorFunc{iota.InputsNonZeroTxIDValidator()}}, false,
+		},
+		{
+			"zero tx ID",
+			args{inputs: []iota.Serializable{
+				&iota.UTXOInput{
+					TransactionID:          randTxHash(),
+					TransactionOutputIndex: 0,
+				},
+				&iota.UTXOInput{
+					TransactionID:          [32]byte{},
+					TransactionOutputIndex: 0,
+				},
+			}, funcs: []iota.InputsValidatorFunc{iota.InputsNonZeroTxIDValidator()}}, true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -123,3 +164,83 @@ func TestInputsValidatorFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestUTXOInput_ID(t *testing.T) {
+	utxoInput, utxoInputData := randUTXOInput()
+	id := utxoInput.ID()
+	assert.Equal(t, utxoInputData[iota.SmallTypeDenotationByteSize:], id[:])
+
+	utxoInput.TransactionOutputIndex++
+	assert.NotEqual(t, id, utxoInput.ID())
+}
+
+func TestUTXOInputID_Hex(t *testing.T) {
+	utxoInput := &iota.UTXOInput{TransactionID: [iota.TransactionIDLength]byte{0xab}, TransactionOutputIndex: 0x0102}
+	id := utxoInput.ID()
+	idHex := id.ToHex()
+	assert.Equal(t, "ab"+strings.Repeat("00", iota.TransactionIDLength-1)+"0201", idHex)
+
+	type test struct {
+		name   string
+		hexStr string
+		err    error
+	}
+	tests := []test{
+		{"ok", idHex, nil},
+		{"not hex", "zz" + idHex[2:], iota.ErrInvalidHex},
+		{"too short", idHex[2:], iota.ErrInvalidBytes},
+		{"too long", idHex + "00", iota.ErrInvalidBytes},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := iota.UTXOInputIDFromHex(tt.hexStr)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "expected %v, got %v", tt.err, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, id, parsed)
+		})
+	}
+}
+
+func TestUTXOInput_DeserializeRefIndexOutOfBounds(t *testing.T) {
+	_, utxoInputData := randUTXOInput()
+	binary.LittleEndian.PutUint16(utxoInputData[iota.UTXOInputSize-iota.UInt16ByteSize:], iota.RefUTXOIndexMax+1)
+
+	_, err := (&iota.UTXOInput{}).Deserialize(utxoInputData, iota.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, iota.ErrRefUTXOIndexInvalid))
+
+	_, err = (&iota.UTXOInput{TransactionOutputIndex: iota.RefUTXOIndexMax + 1}).Serialize(iota.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, iota.ErrRefUTXOIndexInvalid))
+}
+
+func TestUTXOInput_AdjustedMaxOutputIndex(t *testing.T) {
+	defer func(prev uint16) { iota.MaxOutputIndex = prev }(iota.MaxOutputIndex)
+	iota.MaxOutputIndex = 10
+
+	_, err := (&iota.UTXOInput{TransactionOutputIndex: 10}).Serialize(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+
+	data, err := (&iota.UTXOInput{TransactionOutputIndex: 11}).Serialize(iota.DeSeriModeNoValidation)
+	assert.NoError(t, err)
+	_, err = (&iota.UTXOInput{}).Deserialize(data, iota.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, iota.ErrRefUTXOIndexInvalid))
+}
+
+func TestNewUTXOInput(t *testing.T) {
+	txID := randTxHash()
+	input, err := iota.NewUTXOInput(txID[:], 5)
+	assert.NoError(t, err)
+	assert.Equal(t, &iota.UTXOInput{TransactionID: txID, TransactionOutputIndex: 5}, input)
+
+	for _, invalidTxID := range [][]byte{nil, txID[:iota.TransactionIDLength-1], append(txID[:], 0)} {
+		_, err := iota.NewUTXOInput(invalidTxID, 5)
+		assert.True(t, errors.Is(err, iota.ErrInvalidBytes), "no error for transaction ID of length %d", len(invalidTxID))
+	}
+}
+
+func TestInputsNonZeroTxIDValidator(t *testing.T) {
+	err := iota.ValidateInputs(iota.Serializables{&iota.UTXOInput{}}, iota.InputsNonZeroTxIDValidator())
+	assert.True(t, errors.Is(err, iota.ErrInputZeroTransactionID))
+}
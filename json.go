@@ -0,0 +1,94 @@
+package iota
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// The JSON form of the objects mirrors their serialized form: objects which carry a type denotation in their
+// serialized form carry it in a "type" field, by which the selector functions dispatch on decode,
+// and byte arrays are encoded as hex strings.
+
+// jsonTypeDenotation is used to peek at the type of a JSON encoded object.
+type jsonTypeDenotation struct {
+	Type *uint32 `json:"type"`
+}
+
+// unmarshalJSONObject decodes the given JSON object into the Serializable returned by serSel for its "type" field.
+func unmarshalJSONObject(data json.RawMessage, serSel SerializableSelectorFunc) (Serializable, error) {
+	var typeDen jsonTypeDenotation
+	if err := json.Unmarshal(data, &typeDen); err != nil {
+		return nil, err
+	}
+	if typeDen.Type == nil {
+		return nil, fmt.Errorf("%w: JSON object has no type field", ErrDeserializationTypeMismatch)
+	}
+	seri, err := selectObject(serSel, *typeDen.Type)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, seri); err != nil {
+		return nil, err
+	}
+	return seri, nil
+}
+
+// unmarshalJSONObjects decodes the given JSON objects via unmarshalJSONObject.
+func unmarshalJSONObjects(data []json.RawMessage, serSel SerializableSelectorFunc, errCtx string) (Serializables, error) {
+	var seris Serializables
+	for i, objData := range data {
+		seri, err := unmarshalJSONObject(objData, serSel)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode %s at index %d from JSON: %w", errCtx, i, err)
+		}
+		seris = append(seris, seri)
+	}
+	return seris, nil
+}
+
+// unmarshalJSONPayload decodes the given optional JSON payload, absent and null payloads decode to nil.
+func unmarshalJSONPayload(data json.RawMessage, errCtx string) (Serializable, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	payload, err := unmarshalJSONObject(data, PayloadSelector)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode %s from JSON: %w", errCtx, err)
+	}
+	return payload, nil
+}
+
+// checkJSONType returns an error if the type field of a JSON object isn't the expected one.
+func checkJSONType(actual uint32, expected uint32, errCtx string) error {
+	if actual != expected {
+		return fmt.Errorf("%w: JSON %s type must be %d but is %d", ErrDeserializationTypeMismatch, errCtx, expected, actual)
+	}
+	return nil
+}
+
+// decodeHexInto decodes the given hex string into dst, which it must fill exactly.
+func decodeHexInto(dst []byte, s string, errCtx string) error {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("%w: unable to decode %s from hex: %v", ErrInvalidBytes, errCtx, err)
+	}
+	if err := checkExactByteLength(len(dst), len(b)); err != nil {
+		return fmt.Errorf("invalid %s: %w", errCtx, err)
+	}
+	copy(dst, b)
+	return nil
+}
+
+// marshalJSONObjects encodes the given Serializables into JSON objects.
+func marshalJSONObjects(seris Serializables) ([]json.RawMessage, error) {
+	objs := make([]json.RawMessage, len(seris))
+	for i, seri := range seris {
+		objData, err := json.Marshal(seri)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode element at index %d to JSON: %w", i, err)
+		}
+		objs[i] = objData
+	}
+	return objs, nil
+}
@@ -0,0 +1,123 @@
+package iota_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/luca-moser/iota"
+	"github.com/stretchr/testify/assert"
+)
+
+func mixedSignedTransactionPayload() *iota.SignedTransactionPayload {
+	wotsOutput, _ := randSigLockedSingleOutput(iota.AddressWOTS)
+	edOutput, _ := randSigLockedSingleOutput(iota.AddressEd25519)
+	input1, _ := randUTXOInput()
+	input2, _ := randUTXOInput()
+	sigBlock, _ := randEd25519SignatureUnlockBlock()
+	refBlock, _ := referenceUnlockBlock(0)
+	indexation, _ := randIndexationPayload()
+	return &iota.SignedTransactionPayload{
+		Transaction: &iota.UnsignedTransaction{
+			Inputs:  iota.Serializables{input1, input2},
+			Outputs: iota.Serializables{wotsOutput, edOutput},
+			Payload: indexation,
+		},
+		UnlockBlocks: iota.Serializables{sigBlock, refBlock},
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	type test struct {
+		name   string
+		source iota.Serializable
+		target iota.Serializable
+	}
+	tests := []test{
+		func() test {
+			addr, _ := randWOTSAddr()
+			return test{"WOTS address", addr, &iota.WOTSAddress{}}
+		}(),
+		func() test {
+			addr, _ := randEd25519Addr()
+			return test{"Ed25519 address", addr, &iota.Ed25519Address{}}
+		}(),
+		func() test {
+			addr, _ := randAliasAddr()
+			return test{"alias address", addr, &iota.AliasAddress{}}
+		}(),
+		func() test {
+			addr, _ := randNFTAddr()
+			return test{"NFT address", addr, &iota.NFTAddress{}}
+		}(),
+		func() test {
+			unTx, _ := randUnsignedTransaction()
+			return test{"unsigned transaction without payload", unTx, &iota.UnsignedTransaction{}}
+		}(),
+		func() test {
+			return test{"signed transaction with mixed types", mixedSignedTransactionPayload(), &iota.SignedTransactionPayload{}}
+		}(),
+		func() test {
+			msPayload, _ := randMilestonePayload()
+			return test{"milestone payload", msPayload, &iota.MilestonePayload{}}
+		}(),
+		func() test {
+			receipt, _ := randReceiptPayload(3)
+			return test{"receipt payload", receipt, &iota.ReceiptPayload{}}
+		}(),
+		func() test {
+			msg, _ := randMessage(iota.IndexationPayloadID)
+			return test{"message with indexation payload", msg, &iota.Message{}}
+		}(),
+		func() test {
+			msg, _ := randMessage(iota.SignedTransactionPayloadID)
+			msg.Payload = mixedSignedTransactionPayload()
+			return test{"message with mixed signed transaction payload", msg, &iota.Message{}}
+		}(),
+		func() test {
+			msg, _ := randMessage(0)
+			return test{"message without payload", msg, &iota.Message{}}
+		}(),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonData, err := json.Marshal(tt.source)
+			if !assert.NoError(t, err) {
+				return
+			}
+			if !assert.NoError(t, json.Unmarshal(jsonData, tt.target)) {
+				return
+			}
+			assert.EqualValues(t, tt.source, tt.target)
+
+			sourceData, err := tt.source.Serialize(iota.DeSeriModeNoValidation)
+			assert.NoError(t, err)
+			targetData, err := tt.target.Serialize(iota.DeSeriModeNoValidation)
+			assert.NoError(t, err)
+			assert.Equal(t, sourceData, targetData)
+		})
+	}
+}
+
+func TestJSONUnmarshal_Errors(t *testing.T) {
+	type test struct {
+		name   string
+		source string
+		target interface{}
+		err    error
+	}
+	tests := []test{
+		{"output without address type", `{"type":0,"address":{"address":"00"},"amount":1}`, &iota.SigLockedSingleOutput{}, iota.ErrDeserializationTypeMismatch},
+		{"output with unknown address type", `{"type":0,"address":{"type":10,"address":"00"},"amount":1}`, &iota.SigLockedSingleOutput{}, iota.ErrUnknownAddrType},
+		{"address with wrong type", `{"type":0,"address":"00"}`, &iota.Ed25519Address{}, iota.ErrDeserializationTypeMismatch},
+		{"address with invalid hex length", `{"type":1,"address":"0011"}`, &iota.Ed25519Address{}, iota.ErrInvalidBytes},
+		{"transaction with unknown input type", `{"type":0,"inputs":[{"type":7}],"outputs":[],"payload":null}`, &iota.UnsignedTransaction{}, iota.ErrUnknownInputType},
+		{"message with unknown payload type", `{"parents":[],"payload":{"type":99},"nonce":0}`, &iota.Message{}, iota.ErrUnknownPayloadType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := json.Unmarshal([]byte(tt.source), tt.target)
+			assert.True(t, errors.Is(err, tt.err), "expected %v, got %v", tt.err, err)
+		})
+	}
+}
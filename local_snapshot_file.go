@@ -299,6 +299,10 @@ func StreamLocalSnapshotDataFrom(reader io.Reader, compReaderInit CompressionRea
 				addrDataWithoutType = make([]byte, WOTSAddressBytesLength)
 			case *Ed25519Address:
 				addrDataWithoutType = make([]byte, Ed25519AddressBytesLength)
+			case *AliasAddress:
+				addrDataWithoutType = make([]byte, AliasAddressBytesLength)
+			case *NFTAddress:
+				addrDataWithoutType = make([]byte, NFTAddressBytesLength)
 			default:
 				panic("unknown address type")
 			}
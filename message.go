@@ -1,16 +1,49 @@
 package iota
 
 import (
-	"bytes"
-	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 )
 
 const (
+	// The current format version of messages. Deserialize dispatches on the leading version byte.
 	MessageVersion    = 1
 	MessageHashLength = 32
-	// version + 2 msg hashes + uint16 payload length + nonce
-	MessageMinSize = MessageVersionByteSize + 2*MessageHashLength + UInt32ByteSize + UInt64ByteSize
+	// The length of a message ID.
+	MessageIDLength = MessageHashLength
+	// The min amount of parents a message must reference.
+	MinParentsInAMessage = 1
+	// The max amount of parents a message can reference.
+	MaxParentsInAMessage = 8
+	// version + parents count + uint32 payload length + nonce
+	MessageMinSize = MessageVersionByteSize + StructArrayLengthByteSize + UInt32ByteSize + UInt64ByteSize
+	// The max length of a serialized message.
+	MaxMessageLength = 32768
+)
+
+var (
+	ErrMessageMinParentsNotReached             = errors.New(fmt.Sprintf("min %d parent(s) are required within a message", MinParentsInAMessage))
+	ErrMessageMaxParentsExceeded               = errors.New(fmt.Sprintf("max %d parent(s) are allowed within a message", MaxParentsInAMessage))
+	ErrMessageParentsOrderViolatesLexicalOrder = errors.New("message parents must be in their lexical order (byte wise)")
+	ErrMessageTooLarge                         = errors.New(fmt.Sprintf("a message must not exceed %d bytes", MaxMessageLength))
+	ErrMessageWithoutPayload                   = errors.New("message doesn't contain a payload")
+
+	messageParentsArrayRules = ArrayRules{
+		Min:                         MinParentsInAMessage,
+		Max:                         MaxParentsInAMessage,
+		MinErr:                      ErrMessageMinParentsNotReached,
+		MaxErr:                      ErrMessageMaxParentsExceeded,
+		ElementBytesLexicalOrder:    true,
+		ElementBytesLexicalOrderErr: ErrMessageParentsOrderViolatesLexicalOrder,
+		Context:                     "message parents",
+	}
+
+	// MessageIDDomain is the prefix hashed in front of the serialized message to compute its ID.
+	// It separates message IDs from transaction IDs, see TransactionIDDomain.
+	MessageIDDomain = []byte("IOTA-MESSAGE-ID")
 )
 
 // PayloadSelector implements SerializableSelectorFunc for payload types.
@@ -19,102 +52,192 @@ func PayloadSelector(payloadType uint32) (Serializable, error) {
 	switch payloadType {
 	case SignedTransactionPayloadID:
 		seri = &SignedTransactionPayload{}
+	case MilestonePayloadID:
+		seri = &MilestonePayload{}
 	case IndexationPayloadID:
 		seri = &IndexationPayload{}
+	case ReceiptPayloadID:
+		seri = &ReceiptPayload{}
+	case TreasuryTransactionPayloadID:
+		seri = &TreasuryTransaction{}
 	default:
 		return nil, fmt.Errorf("%w: type %d", ErrUnknownPayloadType, payloadType)
 	}
 	return seri, nil
 }
 
-// Message carries a payload and references two other messages.
+// Message carries a payload and references other messages as its parents.
 type Message struct {
-	Parent1 [MessageHashLength]byte `json:"parent_1"`
-	Parent2 [MessageHashLength]byte `json:"parent_2"`
-	Payload Serializable            `json:"payload"`
-	Nonce   uint64                  `json:"nonce"`
+	// The hashes of the messages this message references.
+	Parents SliceOfArraysOf32Bytes `json:"parents"`
+	// The optional payload of the message.
+	Payload Serializable `json:"payload"`
+	// The nonce of the message.
+	Nonce uint64 `json:"nonce"`
+}
+
+// ID computes the ID of the message, which is the BLAKE2b-256 hash of MessageIDDomain followed by its serialized form.
+func (m *Message) ID() ([MessageIDLength]byte, error) {
+	return m.IDWithHasher(DefaultHasher())
+}
+
+// IDWithHasher computes the ID of the message like ID but hashes with the given Hasher.
+func (m *Message) IDWithHasher(hasher Hasher) ([MessageIDLength]byte, error) {
+	data, err := m.Serialize(DeSeriModeNoValidation)
+	if err != nil {
+		return [MessageIDLength]byte{}, fmt.Errorf("unable to compute message ID: %w", err)
+	}
+	return hasher.Hash(MessageIDDomain, data), nil
+}
+
+// DeserializeMessage deserializes a message from untrusted data. Data exceeding MaxMessageLength is rejected
+// with an error wrapping ErrMessageTooLarge before any parsing happens, regardless of the given mode.
+func DeserializeMessage(data []byte, deSeriMode DeSerializationMode) (*Message, error) {
+	if err := checkMessageLength(len(data)); err != nil {
+		return nil, err
+	}
+	m := &Message{}
+	if _, err := m.Deserialize(data, deSeriMode); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PayloadType returns the type of the payload within the given serialized message without deserializing it.
+// Only the parents are skipped and the payload is neither allocated nor validated.
+// An error wrapping ErrMessageWithoutPayload is returned if the message has a zero payload length.
+func PayloadType(data []byte) (uint32, error) {
+	if len(data) < MessageVersionByteSize {
+		return 0, fmt.Errorf("%w: can't read message version", ErrDeserializationNotEnoughData)
+	}
+	if version := data[0]; version != MessageVersion {
+		return 0, fmt.Errorf("%w: message version %d, supported is %d", ErrUnsupportedFormatVersion, version, MessageVersion)
+	}
+
+	var payloadLength, payloadType uint32
+	if _, err := NewDeserializer(data).
+		Skip(MessageVersionByteSize, "message version").
+		SkipSliceOfArrays(MessageHashLength, DeSeriModeNoValidation, nil, "message parents").
+		ReadNum(&payloadLength, "message payload length").
+		AbortIf(func() error {
+			if payloadLength == 0 {
+				return ErrMessageWithoutPayload
+			}
+			return nil
+		}).
+		ReadNum(&payloadType, "message payload type").
+		Done(); err != nil {
+		return 0, err
+	}
+	return payloadType, nil
+}
+
+// checkMessageLength checks that the given length doesn't exceed MaxMessageLength.
+func checkMessageLength(length int) error {
+	if length > MaxMessageLength {
+		return fmt.Errorf("%w: message is %d bytes long", ErrMessageTooLarge, length)
+	}
+	return nil
 }
 
 func (m *Message) Deserialize(data []byte, deSeriMode DeSerializationMode) (int, error) {
+	if len(data) < MessageVersionByteSize {
+		return 0, fmt.Errorf("%w: can't read message version", ErrDeserializationNotEnoughData)
+	}
+
+	switch version := data[0]; version {
+	case MessageVersion:
+		return m.deserializeV1(data, deSeriMode)
+	default:
+		return 0, fmt.Errorf("%w: message version %d, supported is %d", ErrUnsupportedFormatVersion, version, MessageVersion)
+	}
+}
+
+// deserializeV1 deserializes a message in format version 1.
+func (m *Message) deserializeV1(data []byte, deSeriMode DeSerializationMode) (int, error) {
 	if deSeriMode.HasMode(DeSeriModePerformValidation) {
 		if err := checkMinByteLength(MessageMinSize, len(data)); err != nil {
 			return 0, fmt.Errorf("invalid message bytes: %w", err)
 		}
-		if err := checkTypeByte(data, MessageVersion); err != nil {
-			return 0, fmt.Errorf("unable to deserialize message: %w", err)
+		if err := checkMessageLength(len(data)); err != nil {
+			return 0, err
 		}
 	}
-	l := len(data)
-
-	// read parents
-	data = data[MessageVersionByteSize:]
-	copy(m.Parent1[:], data[:MessageHashLength])
-	data = data[MessageHashLength:]
-	copy(m.Parent2[:], data[:MessageHashLength])
-	data = data[MessageHashLength:]
 
-	payload, payloadBytesRead, err := ParsePayload(data, deSeriMode)
+	bytesRead, err := NewDeserializer(data).
+		Skip(MessageVersionByteSize, "message version").
+		ReadSliceOfArraysOf32Bytes(&m.Parents, deSeriMode, &messageParentsArrayRules, "message parents").
+		ReadPayload(&m.Payload, deSeriMode, 0, "message payload").
+		ReadNum(&m.Nonce, "message nonce").
+		Done()
 	if err != nil {
-		return 0, fmt.Errorf("%w: can't parse payload within message", err)
+		return 0, err
 	}
-	m.Payload = payload
 
-	// must have consumed entire data slice minus the nonce
-	data = data[payloadBytesRead:]
-	if leftOver := len(data) - UInt64ByteSize; leftOver != 0 {
+	// must have consumed the entire data slice
+	if leftOver := len(data) - bytesRead; leftOver != 0 {
 		return 0, fmt.Errorf("%w: %d are still available", ErrDeserializationNotAllConsumed, leftOver)
 	}
 
-	m.Nonce = binary.LittleEndian.Uint64(data)
-	return l, nil
+	return bytesRead, nil
 }
 
 func (m *Message) Serialize(deSeriMode DeSerializationMode) ([]byte, error) {
-	if m.Payload == nil {
-		var b [MessageMinSize]byte
-		b[0] = MessageVersion
-		copy(b[MessageVersionByteSize:], m.Parent1[:])
-		copy(b[MessageVersionByteSize+MessageHashLength:], m.Parent2[:])
-		binary.LittleEndian.PutUint32(b[MessageVersionByteSize+MessageHashLength*2:], 0)
-		binary.LittleEndian.PutUint64(b[len(b)-UInt64ByteSize:], m.Nonce)
-		return b[:], nil
-	}
-
-	var b bytes.Buffer
-	if err := b.WriteByte(MessageVersion); err != nil {
-		return nil, err
-	}
-
-	if _, err := b.Write(m.Parent1[:]); err != nil {
+	data, err := NewSerializer().
+		WriteNum(byte(MessageVersion), "message version").
+		WriteSliceOfArraysOf32Bytes(m.Parents, deSeriMode, &messageParentsArrayRules, "message parents").
+		WritePayload(m.Payload, deSeriMode, "message payload").
+		WriteNum(m.Nonce, "message nonce").
+		Serialize()
+	if err != nil {
 		return nil, err
 	}
-
-	if _, err := b.Write(m.Parent2[:]); err != nil {
-		return nil, err
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := checkMessageLength(len(data)); err != nil {
+			return nil, err
+		}
 	}
+	return data, nil
+}
 
-	payloadData, err := m.Payload.Serialize(deSeriMode)
-	if err != nil {
-		return nil, err
-	}
+// WriteTo serializes the Message with WriteToMode and writes it to w, implementing io.WriterTo.
+func (m *Message) WriteTo(w io.Writer) (int64, error) {
+	return writeSerializableTo(w, m)
+}
 
-	payloadLength := uint32(len(payloadData))
+type jsonMessage struct {
+	Parents []string        `json:"parents"`
+	Payload json.RawMessage `json:"payload"`
+	Nonce   uint64          `json:"nonce"`
+}
 
-	if deSeriMode.HasMode(DeSeriModePerformValidation) {
-		// TODO: check payload length
+func (m *Message) MarshalJSON() ([]byte, error) {
+	jMsg := &jsonMessage{Parents: make([]string, len(m.Parents)), Nonce: m.Nonce}
+	for i := range m.Parents {
+		jMsg.Parents[i] = hex.EncodeToString(m.Parents[i][:])
 	}
-
-	if err := binary.Write(&b, binary.LittleEndian, payloadLength); err != nil {
+	var err error
+	if jMsg.Payload, err = json.Marshal(m.Payload); err != nil {
 		return nil, err
 	}
+	return json.Marshal(jMsg)
+}
 
-	if _, err := b.Write(payloadData); err != nil {
-		return nil, err
+func (m *Message) UnmarshalJSON(data []byte) error {
+	jMsg := &jsonMessage{}
+	if err := json.Unmarshal(data, jMsg); err != nil {
+		return err
 	}
-
-	if err := binary.Write(&b, binary.LittleEndian, m.Nonce); err != nil {
-		return nil, err
+	parents := make(SliceOfArraysOf32Bytes, len(jMsg.Parents))
+	for i, parent := range jMsg.Parents {
+		if err := decodeHexInto(parents[i][:], parent, "message parent"); err != nil {
+			return err
+		}
 	}
-
-	return b.Bytes(), nil
+	payload, err := unmarshalJSONPayload(jMsg.Payload, "message payload")
+	if err != nil {
+		return err
+	}
+	m.Parents, m.Payload, m.Nonce = parents, payload, jMsg.Nonce
+	return nil
 }
@@ -0,0 +1,17 @@
+//go:build go1.18
+// +build go1.18
+
+package iota_test
+
+import (
+	"testing"
+
+	"github.com/luca-moser/iota"
+)
+
+func FuzzMessageDeserialize(f *testing.F) {
+	_, noPayloadData := randMessage(0)
+	_, indexationData := randMessage(iota.IndexationPayloadID)
+	_, sigTxData := randMessage(iota.SignedTransactionPayloadID)
+	fuzzDeserialize(f, func() iota.Serializable { return &iota.Message{} }, noPayloadData, indexationData, sigTxData)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/luca-moser/iota"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
 )
 
 func TestMessage_Deserialize(t *testing.T) {
@@ -33,6 +34,7 @@ func TestMessage_Deserialize(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, len(tt.source), bytesRead)
 			assert.EqualValues(t, tt.target, msg)
+			AssertTruncationSafe(t, tt.source, func() iota.Serializable { return &iota.Message{} })
 		})
 	}
 }
@@ -57,3 +59,176 @@ func TestMessage_Serialize(t *testing.T) {
 		})
 	}
 }
+
+func TestMessage_FormatVersion(t *testing.T) {
+	msg, msgData := randMessage(iota.IndexationPayloadID)
+
+	// version 1 round trips
+	deserialized := &iota.Message{}
+	_, err := deserialized.Deserialize(msgData, iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.EqualValues(t, msg, deserialized)
+	reserialized, err := deserialized.Serialize(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.Equal(t, msgData, reserialized)
+
+	// unknown versions are rejected regardless of the mode
+	v2Data := append([]byte{}, msgData...)
+	v2Data[0] = iota.MessageVersion + 1
+	for _, deSeriMode := range []iota.DeSerializationMode{iota.DeSeriModePerformValidation, iota.DeSeriModeNoValidation} {
+		_, err := (&iota.Message{}).Deserialize(v2Data, deSeriMode)
+		assert.True(t, errors.Is(err, iota.ErrUnsupportedFormatVersion))
+	}
+}
+
+func TestMessage_ID(t *testing.T) {
+	msg, msgData := randMessage(iota.IndexationPayloadID)
+
+	id, err := msg.ID()
+	assert.NoError(t, err)
+	assert.Equal(t, blake2b.Sum256(append(append([]byte{}, iota.MessageIDDomain...), msgData...)), id)
+
+	// the ID is stable across serializations and deserializations
+	sameID, err := msg.ID()
+	assert.NoError(t, err)
+	assert.Equal(t, id, sameID)
+
+	deserialized := &iota.Message{}
+	_, err = deserialized.Deserialize(msgData, iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	deserializedID, err := deserialized.ID()
+	assert.NoError(t, err)
+	assert.Equal(t, id, deserializedID)
+
+	msg.Nonce++
+	otherID, err := msg.ID()
+	assert.NoError(t, err)
+	assert.NotEqual(t, id, otherID)
+}
+
+func TestMessage_IDDomainSeparation(t *testing.T) {
+	unTx := &iota.UnsignedTransaction{}
+	msg := &iota.Message{
+		Parents: randSortedParents(1),
+		Payload: &iota.SignedTransactionPayload{Transaction: unTx},
+	}
+
+	msgID, err := msg.ID()
+	assert.NoError(t, err)
+	txID, err := unTx.ID()
+	assert.NoError(t, err)
+	assert.NotEqual(t, msgID, txID)
+
+	// the same bytes hash to different IDs under the two domains
+	unTxData, err := unTx.Serialize(iota.DeSeriModeNoValidation)
+	assert.NoError(t, err)
+	assert.Equal(t, blake2b.Sum256(append(append([]byte{}, iota.TransactionIDDomain...), unTxData...)), txID)
+	assert.NotEqual(t, blake2b.Sum256(append(append([]byte{}, iota.MessageIDDomain...), unTxData...)), txID)
+}
+
+func TestMessage_Parents(t *testing.T) {
+	type test struct {
+		name    string
+		parents iota.SliceOfArraysOf32Bytes
+		err     error
+	}
+	tests := []test{
+		{"min parents", randSortedParents(iota.MinParentsInAMessage), nil},
+		{"max parents", randSortedParents(iota.MaxParentsInAMessage), nil},
+		{"no parents", nil, iota.ErrMessageMinParentsNotReached},
+		{"too many parents", randSortedParents(iota.MaxParentsInAMessage + 1), iota.ErrMessageMaxParentsExceeded},
+		{"parents not in lexical order", iota.SliceOfArraysOf32Bytes{{2}, {1}}, iota.ErrMessageParentsOrderViolatesLexicalOrder},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := &iota.Message{Parents: tt.parents, Nonce: 1337}
+			_, seriErr := msg.Serialize(fullValidation)
+			data, err := msg.Serialize(iota.DeSeriModeNoValidation)
+			assert.NoError(t, err)
+			_, deSeriErr := (&iota.Message{}).Deserialize(data, fullValidation)
+
+			if tt.err != nil {
+				assert.True(t, errors.Is(seriErr, tt.err), "unexpected serialization error %v", seriErr)
+				assert.True(t, errors.Is(deSeriErr, tt.err), "unexpected deserialization error %v", deSeriErr)
+				return
+			}
+			assert.NoError(t, seriErr)
+			assert.NoError(t, deSeriErr)
+		})
+	}
+}
+
+func TestMessage_MaxLength(t *testing.T) {
+	// returns a message which serializes to exactly the given length
+	msgOfLength := func(length int) *iota.Message {
+		payload, _ := randIndexationPayload(0)
+		msg := &iota.Message{Parents: randSortedParents(1), Payload: payload}
+		data, err := msg.Serialize(iota.DeSeriModeNoValidation)
+		must(err)
+		payload.Data = randBytes(length - len(data))
+		return msg
+	}
+
+	type test struct {
+		name   string
+		length int
+		err    error
+	}
+	tests := []test{
+		{"exactly max", iota.MaxMessageLength, nil},
+		{"one over max", iota.MaxMessageLength + 1, iota.ErrMessageTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := msgOfLength(tt.length)
+			_, seriErr := msg.Serialize(iota.DeSeriModePerformValidation)
+			data, err := msg.Serialize(iota.DeSeriModeNoValidation)
+			assert.NoError(t, err)
+			assert.Len(t, data, tt.length)
+			_, deSeriErr := (&iota.Message{}).Deserialize(data, iota.DeSeriModePerformValidation)
+			_, strictErr := iota.DeserializeMessage(data, iota.DeSeriModeNoValidation)
+
+			if tt.err != nil {
+				assert.True(t, errors.Is(seriErr, tt.err), "unexpected serialization error %v", seriErr)
+				assert.True(t, errors.Is(deSeriErr, tt.err), "unexpected deserialization error %v", deSeriErr)
+				assert.True(t, errors.Is(strictErr, tt.err), "unexpected deserialization error %v", strictErr)
+				return
+			}
+			assert.NoError(t, seriErr)
+			assert.NoError(t, deSeriErr)
+			assert.NoError(t, strictErr)
+		})
+	}
+}
+
+func TestPayloadType(t *testing.T) {
+	_, indexationData := randMessage(iota.IndexationPayloadID)
+	_, sigTxData := randMessage(iota.SignedTransactionPayloadID)
+	noPayloadData, err := (&iota.Message{Parents: randSortedParents(2)}).Serialize(iota.DeSeriModePerformValidation)
+	must(err)
+
+	type test struct {
+		name        string
+		data        []byte
+		payloadType uint32
+		err         error
+	}
+	tests := []test{
+		{"indexation payload", indexationData, iota.IndexationPayloadID, nil},
+		{"signed transaction payload", sigTxData, iota.SignedTransactionPayloadID, nil},
+		{"no payload", noPayloadData, 0, iota.ErrMessageWithoutPayload},
+		{"unsupported version", append([]byte{iota.MessageVersion + 1}, indexationData[1:]...), 0, iota.ErrUnsupportedFormatVersion},
+		{"truncated parents", indexationData[:iota.MessageVersionByteSize+iota.StructArrayLengthByteSize+1], 0, iota.ErrDeserializationNotEnoughData},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payloadType, err := iota.PayloadType(tt.data)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "unexpected error %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.payloadType, payloadType)
+		})
+	}
+}
@@ -1,67 +1,152 @@
 package iota
 
 import (
-	"encoding/binary"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 )
 
 const (
 	MilestonePayloadID                  uint32 = 1
 	MilestoneInclusionMerkleProofLength        = 64
-	MilestoneSignatureLength                   = 64
+	MilestoneSignatureLength                   = ed25519.SignatureSize
 	MilestoneHashLength                        = 32
-	MilestonePayloadSize                       = TypeDenotationByteSize + UInt64ByteSize + UInt64ByteSize + MilestoneInclusionMerkleProofLength + MilestoneSignatureLength
+	// The min amount of signatures a milestone must hold.
+	MinSignaturesInAMilestone = 1
+	// type + index + timestamp + parents count + inclusion merkle proof + signatures count
+	MilestonePayloadMinSize = TypeDenotationByteSize + UInt32ByteSize + UInt64ByteSize + StructArrayLengthByteSize +
+		MilestoneInclusionMerkleProofLength + StructArrayLengthByteSize
 )
 
-// MilestonePayload holds the inclusion merkle proof and milestone signature.
+var (
+	ErrMilestoneTooFewSignatures                 = errors.New(fmt.Sprintf("a milestone must hold at least %d signature(s)", MinSignaturesInAMilestone))
+	ErrMilestoneParentsOrderViolatesLexicalOrder = errors.New("milestone parents must be in their lexical order (byte wise)")
+
+	milestoneParentsArrayRules = ArrayRules{
+		ElementBytesLexicalOrder:    true,
+		ElementBytesLexicalOrderErr: ErrMilestoneParentsOrderViolatesLexicalOrder,
+	}
+
+	milestoneSignaturesArrayRules = ArrayRules{
+		Min:     MinSignaturesInAMilestone,
+		MinErr:  ErrMilestoneTooFewSignatures,
+		Context: "milestone signatures",
+	}
+)
+
+// MilestonePayload holds the inclusion merkle proof and milestone signatures.
 type MilestonePayload struct {
-	Index                uint64                                    `json:"index"`
-	Timestamp            uint64                                    `json:"timestamp"`
+	// The index of the milestone.
+	Index uint32 `json:"index"`
+	// The unix time at which the milestone was issued.
+	Timestamp uint64 `json:"timestamp"`
+	// The hashes of the messages the milestone references.
+	Parents SliceOfArraysOf32Bytes `json:"parents"`
+	// The merkle proof of the transactions the milestone confirms.
 	InclusionMerkleProof [MilestoneInclusionMerkleProofLength]byte `json:"inclusion_merkle_proof"`
-	Signature            [MilestoneSignatureLength]byte            `json:"signature"`
+	// The Ed25519 signatures of the milestone.
+	Signatures SliceOfArraysOf64Bytes `json:"signatures"`
 }
 
 func (m *MilestonePayload) Deserialize(data []byte, deSeriMode DeSerializationMode) (int, error) {
 	if deSeriMode.HasMode(DeSeriModePerformValidation) {
-		if err := checkMinByteLength(MilestonePayloadSize, len(data)); err != nil {
+		if err := checkMinByteLength(MilestonePayloadMinSize, len(data)); err != nil {
 			return 0, err
 		}
 		if err := checkType(data, MilestonePayloadID); err != nil {
 			return 0, fmt.Errorf("unable to deserialize milestone payload: %w", err)
 		}
 	}
-	data = data[TypeDenotationByteSize:]
 
-	// read inex
-	m.Index = binary.LittleEndian.Uint64(data)
-	data = data[UInt64ByteSize:]
+	return NewDeserializer(data).
+		Skip(TypeDenotationByteSize, "milestone payload type").
+		ReadNum(&m.Index, "milestone index").
+		ReadNum(&m.Timestamp, "milestone timestamp").
+		ReadSliceOfArraysOf32Bytes(&m.Parents, deSeriMode, &milestoneParentsArrayRules, "milestone parents").
+		ReadBytes(m.InclusionMerkleProof[:], "milestone inclusion merkle proof").
+		ReadSliceOfArraysOf64Bytes(&m.Signatures, deSeriMode, &milestoneSignaturesArrayRules, "milestone signatures").
+		Done()
+}
 
-	// read timestamp
-	m.Timestamp = binary.LittleEndian.Uint64(data)
-	data = data[UInt64ByteSize:]
+func (m *MilestonePayload) ValidateBytes(data []byte, deSeriMode DeSerializationMode) (int, error) {
+	return NewDeserializer(data).
+		Skip(TypeDenotationByteSize+UInt32ByteSize+UInt64ByteSize, "milestone index and timestamp").
+		SkipSliceOfArrays(MilestoneHashLength, deSeriMode, &milestoneParentsArrayRules, "milestone parents").
+		Skip(MilestoneInclusionMerkleProofLength, "milestone inclusion merkle proof").
+		SkipSliceOfArrays(MilestoneSignatureLength, deSeriMode, &milestoneSignaturesArrayRules, "milestone signatures").
+		Done()
+}
 
-	// read merkle proof and signature
-	copy(m.InclusionMerkleProof[:], data[:MilestoneInclusionMerkleProofLength])
-	data = data[MilestoneInclusionMerkleProofLength:]
-	copy(m.Signature[:], data[:MilestoneSignatureLength])
+func (m *MilestonePayload) Serialize(deSeriMode DeSerializationMode) ([]byte, error) {
+	return NewSerializer().
+		WriteNum(MilestonePayloadID, "milestone payload type").
+		WriteNum(m.Index, "milestone index").
+		WriteNum(m.Timestamp, "milestone timestamp").
+		WriteSliceOfArraysOf32Bytes(m.Parents, deSeriMode, &milestoneParentsArrayRules, "milestone parents").
+		WriteBytes(m.InclusionMerkleProof[:], "milestone inclusion merkle proof").
+		WriteSliceOfArraysOf64Bytes(m.Signatures, deSeriMode, &milestoneSignaturesArrayRules, "milestone signatures").
+		Serialize()
+}
 
-	if deSeriMode.HasMode(DeSeriModePerformValidation) {
-		// TODO: validation
-	}
+// WriteTo serializes the MilestonePayload with WriteToMode and writes it to w, implementing io.WriterTo.
+func (m *MilestonePayload) WriteTo(w io.Writer) (int64, error) {
+	return writeSerializableTo(w, m)
+}
 
-	return MilestonePayloadSize, nil
+type jsonMilestonePayload struct {
+	Type                 uint32   `json:"type"`
+	Index                uint32   `json:"index"`
+	Timestamp            uint64   `json:"timestamp"`
+	Parents              []string `json:"parents"`
+	InclusionMerkleProof string   `json:"inclusion_merkle_proof"`
+	Signatures           []string `json:"signatures"`
 }
 
-func (m *MilestonePayload) Serialize(deSeriMode DeSerializationMode) ([]byte, error) {
-	var b [MilestonePayloadSize]byte
-	binary.LittleEndian.PutUint32(b[:], MilestonePayloadID)
-	binary.LittleEndian.PutUint64(b[TypeDenotationByteSize:], m.Index)
-	binary.LittleEndian.PutUint64(b[TypeDenotationByteSize+UInt64ByteSize:], m.Timestamp)
-	offset := TypeDenotationByteSize + UInt64ByteSize + UInt64ByteSize
-	copy(b[offset:], m.InclusionMerkleProof[:])
-	copy(b[offset+MilestoneInclusionMerkleProofLength:], m.Signature[:])
-	if deSeriMode.HasMode(DeSeriModePerformValidation) {
-		// TODO: validation
+func (m *MilestonePayload) MarshalJSON() ([]byte, error) {
+	jPayload := &jsonMilestonePayload{
+		Type:                 MilestonePayloadID,
+		Index:                m.Index,
+		Timestamp:            m.Timestamp,
+		Parents:              make([]string, len(m.Parents)),
+		InclusionMerkleProof: hex.EncodeToString(m.InclusionMerkleProof[:]),
+		Signatures:           make([]string, len(m.Signatures)),
+	}
+	for i := range m.Parents {
+		jPayload.Parents[i] = hex.EncodeToString(m.Parents[i][:])
+	}
+	for i := range m.Signatures {
+		jPayload.Signatures[i] = hex.EncodeToString(m.Signatures[i][:])
+	}
+	return json.Marshal(jPayload)
+}
+
+func (m *MilestonePayload) UnmarshalJSON(data []byte) error {
+	jPayload := &jsonMilestonePayload{}
+	if err := json.Unmarshal(data, jPayload); err != nil {
+		return err
+	}
+	if err := checkJSONType(jPayload.Type, MilestonePayloadID, "milestone payload"); err != nil {
+		return err
+	}
+	parents := make(SliceOfArraysOf32Bytes, len(jPayload.Parents))
+	for i, parent := range jPayload.Parents {
+		if err := decodeHexInto(parents[i][:], parent, "milestone parent"); err != nil {
+			return err
+		}
+	}
+	var proof [MilestoneInclusionMerkleProofLength]byte
+	if err := decodeHexInto(proof[:], jPayload.InclusionMerkleProof, "milestone inclusion merkle proof"); err != nil {
+		return err
+	}
+	sigs := make(SliceOfArraysOf64Bytes, len(jPayload.Signatures))
+	for i, sig := range jPayload.Signatures {
+		if err := decodeHexInto(sigs[i][:], sig, "milestone signature"); err != nil {
+			return err
+		}
 	}
-	return b[:], nil
+	m.Index, m.Timestamp, m.Parents, m.InclusionMerkleProof, m.Signatures = jPayload.Index, jPayload.Timestamp, parents, proof, sigs
+	return nil
 }
@@ -0,0 +1,15 @@
+//go:build go1.18
+// +build go1.18
+
+package iota_test
+
+import (
+	"testing"
+
+	"github.com/luca-moser/iota"
+)
+
+func FuzzMilestonePayloadDeserialize(f *testing.F) {
+	_, data := randMilestonePayload()
+	fuzzDeserialize(f, func() iota.Serializable { return &iota.MilestonePayload{} }, data)
+}
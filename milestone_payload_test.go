@@ -33,6 +33,7 @@ func TestMilestonePayload_Deserialize(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, len(tt.source), bytesRead)
 			assert.EqualValues(t, tt.target, msPayload)
+			AssertTruncationSafe(t, tt.source, func() iota.Serializable { return &iota.MilestonePayload{} })
 		})
 	}
 }
@@ -57,3 +58,45 @@ func TestMilestonePayload_Serialize(t *testing.T) {
 		})
 	}
 }
+
+func TestMilestonePayload_Validation(t *testing.T) {
+	type test struct {
+		name   string
+		modify func(msPayload *iota.MilestonePayload)
+		err    error
+	}
+	tests := []test{
+		{"ok", func(msPayload *iota.MilestonePayload) {}, nil},
+		{"parents not in lexical order", func(msPayload *iota.MilestonePayload) {
+			msPayload.Parents = iota.SliceOfArraysOf32Bytes{{2}, {1}}
+		}, iota.ErrMilestoneParentsOrderViolatesLexicalOrder},
+		{"no signatures", func(msPayload *iota.MilestonePayload) {
+			msPayload.Signatures = nil
+		}, iota.ErrMilestoneTooFewSignatures},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msPayload, _ := randMilestonePayload()
+			tt.modify(msPayload)
+
+			_, seriErr := msPayload.Serialize(fullValidation)
+			data, err := msPayload.Serialize(iota.DeSeriModeNoValidation)
+			assert.NoError(t, err)
+
+			deserialized := &iota.MilestonePayload{}
+			_, deSeriErr := deserialized.Deserialize(data, fullValidation)
+			_, validateErr := iota.ValidateBytes(data, fullValidation, iota.PayloadSelector)
+
+			if tt.err != nil {
+				assert.True(t, errors.Is(seriErr, tt.err), "unexpected serialization error %v", seriErr)
+				assert.True(t, errors.Is(deSeriErr, tt.err), "unexpected deserialization error %v", deSeriErr)
+				assert.True(t, errors.Is(validateErr, tt.err), "unexpected validation error %v", validateErr)
+				return
+			}
+			assert.NoError(t, seriErr)
+			assert.NoError(t, deSeriErr)
+			assert.NoError(t, validateErr)
+			assert.EqualValues(t, msPayload, deserialized)
+		})
+	}
+}
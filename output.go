@@ -2,9 +2,10 @@ package iota
 
 import (
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"strings"
+	"io"
 )
 
 // Defines the type of outputs.
@@ -12,162 +13,472 @@ type OutputType = byte
 
 const (
 	// Denotes a type of output which is locked by a signature and deposits onto a single address.
-	OutputSigLockedSingleDeposit OutputType = iota
+	OutputSigLockedSingleOutput OutputType = iota
+	// Denotes a type of output which is locked by a signature and allows its address to receive dust outputs.
+	OutputSigLockedDustAllowanceOutput
+	// Denotes an output holding the funds of the treasury.
+	OutputTreasuryOutput OutputType = 2
 
-	// The size of a sig locked single deposit containing a WOTS address as its deposit address.
-	SigLockedSingleDepositWOTSAddrBytesSize = SmallTypeDenotationByteSize + WOTSAddressSerializedBytesSize + UInt64ByteSize
-	// The size of a sig locked single deposit containing an Ed25519 address as its deposit address.
-	SigLockedSingleDepositEd25519AddrBytesSize = SmallTypeDenotationByteSize + Ed25519AddressSerializedBytesSize + UInt64ByteSize
+	// The size of a sig locked single output containing a WOTS address as its deposit address.
+	SigLockedSingleOutputWOTSAddrBytesSize = SmallTypeDenotationByteSize + WOTSAddressSerializedBytesSize + UInt64ByteSize
+	// The size of a sig locked single output containing an Ed25519 address as its deposit address.
+	SigLockedSingleOutputEd25519AddrBytesSize = SmallTypeDenotationByteSize + Ed25519AddressSerializedBytesSize + UInt64ByteSize
 
-	// Defines the minimum size a sig locked single deposit must be.
-	SigLockedSingleDepositBytesMinSize = SigLockedSingleDepositEd25519AddrBytesSize
-	// Defines the offset at which the address portion within a sig locked single deposit begins.
-	SigLockedSingleDepositAddressOffset = SmallTypeDenotationByteSize
+	// The size of a sig locked single output containing an alias address as its deposit address.
+	SigLockedSingleOutputAliasAddrBytesSize = SmallTypeDenotationByteSize + AliasAddressSerializedBytesSize + UInt64ByteSize
+	// The size of a sig locked single output containing an NFT address as its deposit address.
+	SigLockedSingleOutputNFTAddrBytesSize = SmallTypeDenotationByteSize + NFTAddressSerializedBytesSize + UInt64ByteSize
+
+	// Defines the minimum size a sig locked single output must be.
+	SigLockedSingleOutputBytesMinSize = SigLockedSingleOutputAliasAddrBytesSize
+	// Defines the offset at which the address portion within a sig locked single output begins.
+	SigLockedSingleOutputAddressOffset = SmallTypeDenotationByteSize
+
+	// The size of a sig locked dust allowance output containing an Ed25519 address as its deposit address.
+	SigLockedDustAllowanceOutputEd25519AddrBytesSize = SmallTypeDenotationByteSize + Ed25519AddressSerializedBytesSize + UInt64ByteSize
+	// Defines the minimum size a sig locked dust allowance output must be, which is the size with an alias address.
+	SigLockedDustAllowanceOutputBytesMinSize = SmallTypeDenotationByteSize + AliasAddressSerializedBytesSize + UInt64ByteSize
+	// The minimum amount a sig locked dust allowance output must deposit.
+	DustAllowanceMinimum = 1_000_000
+
+	// output type + amount
+	TreasuryOutputSize = SmallTypeDenotationByteSize + UInt64ByteSize
 )
 
 var (
-	ErrDepositAmountMustBeGreaterThanZero = errors.New("deposit amount must be greater than zero")
+	ErrDepositAmountMustBeGreaterThanZero     = errors.New("deposit amount must be greater than zero")
+	ErrZeroAddress                            = errors.New("address must not be all zero")
+	ErrTreasuryOutputAmountExceedsTotalSupply = errors.New("treasury output amount exceeds the total supply")
+	ErrDustAllowanceAmountTooLow              = errors.New(fmt.Sprintf("dust allowance outputs must deposit at least %d", DustAllowanceMinimum))
+	ErrDustAllowanceAddrNotUnique             = errors.New("dust allowance outputs must each deposit to a unique address")
 )
 
 // OutputSelector implements SerializableSelectorFunc for output types.
 func OutputSelector(outputType uint32) (Serializable, error) {
 	var seri Serializable
 	switch byte(outputType) {
-	case OutputSigLockedSingleDeposit:
-		seri = &SigLockedSingleDeposit{}
+	case OutputSigLockedSingleOutput:
+		seri = &SigLockedSingleOutput{}
+	case OutputSigLockedDustAllowanceOutput:
+		seri = &SigLockedDustAllowanceOutput{}
+	case OutputTreasuryOutput:
+		seri = &TreasuryOutput{}
 	default:
 		return nil, fmt.Errorf("%w: type %d", ErrUnknownOutputType, outputType)
 	}
 	return seri, nil
 }
 
-// SigLockedSingleDeposit is an output type which can be unlocked via a signature. It deposits onto one single address.
-type SigLockedSingleDeposit struct {
+// SigLockedSingleOutput is an output type which can be unlocked via a signature. It deposits onto one single address.
+type SigLockedSingleOutput struct {
 	// The actual address.
 	Address Serializable `json:"address"`
 	// The amount to deposit.
 	Amount uint64 `json:"amount"`
 }
 
-func (s *SigLockedSingleDeposit) Deserialize(data []byte, deSeriMode DeSerializationMode) (int, error) {
+func (s *SigLockedSingleOutput) Deserialize(data []byte, deSeriMode DeSerializationMode) (int, error) {
 	if deSeriMode.HasMode(DeSeriModePerformValidation) {
-		if err := checkMinByteLength(SigLockedSingleDepositBytesMinSize, len(data)); err != nil {
+		if err := checkMinByteLength(SigLockedSingleOutputBytesMinSize, len(data)); err != nil {
 			return 0, err
 		}
-		if err := checkTypeByte(data, OutputSigLockedSingleDeposit); err != nil {
-			return 0, fmt.Errorf("unable to deserialize signature locked single deposit: %w", err)
+		if err := checkTypeByte(data, OutputSigLockedSingleOutput); err != nil {
+			return 0, fmt.Errorf("unable to deserialize signature locked single output: %w", err)
 		}
 	}
 
-	data = data[SmallTypeDenotationByteSize:]
-	addr, addrBytesRead, err := DeserializeObject(data, deSeriMode, TypeDenotationByte, AddressSelector)
-	if err != nil {
-		return 0, err
+	return NewDeserializer(data).
+		Skip(SmallTypeDenotationByteSize, "signature locked single output type").
+		ReadObject(&s.Address, deSeriMode, TypeDenotationByte, AddressSelector, "signature locked single output address").
+		ReadNum(&s.Amount, "signature locked single output amount").
+		AbortIf(func() error {
+			if deSeriMode.HasMode(DeSeriModePerformValidation) {
+				return outputAmountValidator(-1, s)
+			}
+			return nil
+		}).
+		Done()
+}
+
+func (s *SigLockedSingleOutput) ValidateBytes(data []byte, deSeriMode DeSerializationMode) (int, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := checkTypeByte(data, OutputSigLockedSingleOutput); err != nil {
+			return 0, fmt.Errorf("invalid signature locked single output bytes: %w", err)
+		}
+	}
+	var amount uint64
+	return NewDeserializer(data).
+		Skip(SmallTypeDenotationByteSize, "signature locked single output type").
+		SkipObject(deSeriMode, TypeDenotationByte, AddressSelector, "signature locked single output address").
+		ReadNum(&amount, "signature locked single output amount").
+		AbortIf(func() error {
+			if deSeriMode.HasMode(DeSeriModePerformValidation) {
+				return outputAmountValidator(-1, &SigLockedSingleOutput{Amount: amount})
+			}
+			return nil
+		}).
+		Done()
+}
+
+func (s *SigLockedSingleOutput) Serialize(deSeriMode DeSerializationMode) (data []byte, err error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := outputAmountValidator(-1, s); err != nil {
+			return nil, err
+		}
 	}
-	s.Address = addr
 
-	data = data[addrBytesRead:]
+	size := s.SerializedSize()
+	if size == 0 {
+		return nil, ErrUnknownAddrType
+	}
+	b := make([]byte, size)
+	if _, err := s.serializeInto(b, deSeriMode); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
 
-	// read amount of the deposit
-	s.Amount = binary.LittleEndian.Uint64(data)
+// WriteTo serializes the SigLockedSingleOutput with WriteToMode and writes it to w, implementing io.WriterTo.
+func (s *SigLockedSingleOutput) WriteTo(w io.Writer) (int64, error) {
+	return writeSerializableTo(w, s)
+}
 
+// SerializedSize returns the length of the serialized output, or 0 if it holds an unknown address type.
+func (s *SigLockedSingleOutput) SerializedSize() int {
+	switch s.Address.(type) {
+	case *WOTSAddress:
+		return SigLockedSingleOutputWOTSAddrBytesSize
+	case *Ed25519Address:
+		return SigLockedSingleOutputEd25519AddrBytesSize
+	case *AliasAddress:
+		return SigLockedSingleOutputAliasAddrBytesSize
+	case *NFTAddress:
+		return SigLockedSingleOutputNFTAddrBytesSize
+	default:
+		return 0
+	}
+}
+
+func (s *SigLockedSingleOutput) SerializeInto(buf []byte, deSeriMode DeSerializationMode) (int, error) {
 	if deSeriMode.HasMode(DeSeriModePerformValidation) {
 		if err := outputAmountValidator(-1, s); err != nil {
 			return 0, err
 		}
 	}
+	switch s.Address.(type) {
+	case *WOTSAddress, *Ed25519Address, *AliasAddress, *NFTAddress:
+	default:
+		return 0, ErrUnknownAddrType
+	}
+	return s.serializeInto(buf, deSeriMode)
+}
 
-	return SmallTypeDenotationByteSize + addrBytesRead + UInt64ByteSize, nil
+// serializeInto writes the output into buf without validating it.
+func (s *SigLockedSingleOutput) serializeInto(buf []byte, deSeriMode DeSerializationMode) (int, error) {
+	return serializeSigLockedOutputInto(buf, OutputSigLockedSingleOutput, s.Address, s.Amount, deSeriMode)
 }
 
-func (s *SigLockedSingleDeposit) Serialize(deSeriMode DeSerializationMode) (data []byte, err error) {
+// serializeSigLockedOutputInto writes the output type, address and amount of a signature locked output into buf.
+func serializeSigLockedOutputInto(buf []byte, outputType OutputType, addr Serializable, amount uint64, deSeriMode DeSerializationMode) (int, error) {
+	if err := checkSerializationBufferSize(SmallTypeDenotationByteSize, len(buf)); err != nil {
+		return 0, err
+	}
+	buf[0] = outputType
+	offset := SmallTypeDenotationByteSize
+	addrBytesWritten, err := SerializeInto(addr, buf[offset:], deSeriMode)
+	if err != nil {
+		return 0, err
+	}
+	offset += addrBytesWritten
+	if err := checkSerializationBufferSize(offset+UInt64ByteSize, len(buf)); err != nil {
+		return 0, err
+	}
+	binary.LittleEndian.PutUint64(buf[offset:], amount)
+	return offset + UInt64ByteSize, nil
+}
+
+// SigLockedDustAllowanceOutput is an output type which can be unlocked via a signature. It deposits onto one single address
+// and allows that address to receive dust outputs.
+type SigLockedDustAllowanceOutput struct {
+	// The actual address.
+	Address Serializable `json:"address"`
+	// The amount to deposit.
+	Amount uint64 `json:"amount"`
+}
+
+func (s *SigLockedDustAllowanceOutput) Deserialize(data []byte, deSeriMode DeSerializationMode) (int, error) {
 	if deSeriMode.HasMode(DeSeriModePerformValidation) {
-		if err := outputAmountValidator(-1, s); err != nil {
+		if err := checkMinByteLength(SigLockedDustAllowanceOutputBytesMinSize, len(data)); err != nil {
+			return 0, err
+		}
+		if err := checkTypeByte(data, OutputSigLockedDustAllowanceOutput); err != nil {
+			return 0, fmt.Errorf("unable to deserialize signature locked dust allowance output: %w", err)
+		}
+	}
+
+	return NewDeserializer(data).
+		Skip(SmallTypeDenotationByteSize, "signature locked dust allowance output type").
+		ReadObject(&s.Address, deSeriMode, TypeDenotationByte, AddressSelector, "signature locked dust allowance output address").
+		ReadNum(&s.Amount, "signature locked dust allowance output amount").
+		AbortIf(func() error {
+			if deSeriMode.HasMode(DeSeriModePerformValidation) {
+				return dustAllowanceAmountValidator(-1, s)
+			}
+			return nil
+		}).
+		Done()
+}
+
+func (s *SigLockedDustAllowanceOutput) ValidateBytes(data []byte, deSeriMode DeSerializationMode) (int, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := checkTypeByte(data, OutputSigLockedDustAllowanceOutput); err != nil {
+			return 0, fmt.Errorf("invalid signature locked dust allowance output bytes: %w", err)
+		}
+	}
+	var amount uint64
+	return NewDeserializer(data).
+		Skip(SmallTypeDenotationByteSize, "signature locked dust allowance output type").
+		SkipObject(deSeriMode, TypeDenotationByte, AddressSelector, "signature locked dust allowance output address").
+		ReadNum(&amount, "signature locked dust allowance output amount").
+		AbortIf(func() error {
+			if deSeriMode.HasMode(DeSeriModePerformValidation) {
+				return dustAllowanceAmountValidator(-1, &SigLockedDustAllowanceOutput{Amount: amount})
+			}
+			return nil
+		}).
+		Done()
+}
+
+func (s *SigLockedDustAllowanceOutput) Serialize(deSeriMode DeSerializationMode) ([]byte, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := dustAllowanceAmountValidator(-1, s); err != nil {
 			return nil, err
 		}
 	}
 
-	var b []byte
+	size := s.SerializedSize()
+	if size == 0 {
+		return nil, ErrUnknownAddrType
+	}
+	b := make([]byte, size)
+	if _, err := serializeSigLockedOutputInto(b, OutputSigLockedDustAllowanceOutput, s.Address, s.Amount, deSeriMode); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// WriteTo serializes the SigLockedDustAllowanceOutput with WriteToMode and writes it to w, implementing io.WriterTo.
+func (s *SigLockedDustAllowanceOutput) WriteTo(w io.Writer) (int64, error) {
+	return writeSerializableTo(w, s)
+}
+
+func (s *SigLockedDustAllowanceOutput) SerializeInto(buf []byte, deSeriMode DeSerializationMode) (int, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := dustAllowanceAmountValidator(-1, s); err != nil {
+			return 0, err
+		}
+	}
 	switch s.Address.(type) {
-	case *WOTSAddress:
-		b = make([]byte, SigLockedSingleDepositWOTSAddrBytesSize)
-	case *Ed25519Address:
-		b = make([]byte, SigLockedSingleDepositEd25519AddrBytesSize)
+	case *WOTSAddress, *Ed25519Address, *AliasAddress, *NFTAddress:
 	default:
-		return nil, ErrUnknownAddrType
+		return 0, ErrUnknownAddrType
 	}
+	return serializeSigLockedOutputInto(buf, OutputSigLockedDustAllowanceOutput, s.Address, s.Amount, deSeriMode)
+}
 
-	b[0] = OutputSigLockedSingleDeposit
-	addrBytes, err := s.Address.Serialize(deSeriMode)
-	if err != nil {
+// SerializedSize returns the length of the serialized output, or 0 if it holds an unknown address type.
+func (s *SigLockedDustAllowanceOutput) SerializedSize() int {
+	switch s.Address.(type) {
+	case *WOTSAddress, *Ed25519Address, *AliasAddress, *NFTAddress:
+		return SmallTypeDenotationByteSize + s.Address.(SizeHinter).SerializedSize() + UInt64ByteSize
+	default:
+		return 0
+	}
+}
+
+// TreasuryOutput is an output which holds the funds of the treasury.
+type TreasuryOutput struct {
+	// The amount of funds held by the treasury.
+	Amount uint64 `json:"amount"`
+}
+
+func (t *TreasuryOutput) Deserialize(data []byte, deSeriMode DeSerializationMode) (int, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := checkMinByteLength(TreasuryOutputSize, len(data)); err != nil {
+			return 0, fmt.Errorf("invalid treasury output bytes: %w", err)
+		}
+		if err := checkTypeByte(data, OutputTreasuryOutput); err != nil {
+			return 0, fmt.Errorf("unable to deserialize treasury output: %w", err)
+		}
+	}
+
+	return NewDeserializer(data).
+		Skip(SmallTypeDenotationByteSize, "treasury output type").
+		ReadNum(&t.Amount, "treasury output amount").
+		AbortIf(func() error {
+			if deSeriMode.HasMode(DeSeriModePerformValidation) {
+				return t.validate()
+			}
+			return nil
+		}).
+		Done()
+}
+
+func (t *TreasuryOutput) Serialize(deSeriMode DeSerializationMode) ([]byte, error) {
+	var b [TreasuryOutputSize]byte
+	if _, err := t.SerializeInto(b[:], deSeriMode); err != nil {
 		return nil, err
 	}
-	copy(b[SmallTypeDenotationByteSize:], addrBytes)
-	binary.LittleEndian.PutUint64(b[len(b)-UInt64ByteSize:], s.Amount)
-	return b, nil
+	return b[:], nil
+}
+
+// WriteTo writes the serialized TreasuryOutput to w without allocating, implementing io.WriterTo.
+func (t *TreasuryOutput) WriteTo(w io.Writer) (int64, error) {
+	var b [TreasuryOutputSize]byte
+	return writeFixedSizeTo(w, t, b[:])
+}
+
+func (t *TreasuryOutput) SerializeInto(buf []byte, deSeriMode DeSerializationMode) (int, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := t.validate(); err != nil {
+			return 0, err
+		}
+	}
+	if err := checkSerializationBufferSize(TreasuryOutputSize, len(buf)); err != nil {
+		return 0, err
+	}
+	buf[0] = OutputTreasuryOutput
+	binary.LittleEndian.PutUint64(buf[SmallTypeDenotationByteSize:], t.Amount)
+	return TreasuryOutputSize, nil
+}
+
+func (t *TreasuryOutput) SerializedSize() int {
+	return TreasuryOutputSize
+}
+
+func (t *TreasuryOutput) validate() error {
+	if t.Amount > TokenSupply {
+		return fmt.Errorf("%w: %d", ErrTreasuryOutputAmountExceedsTotalSupply, t.Amount)
+	}
+	return nil
 }
 
 // OutputsValidatorFunc which given the index of an output and the output itself, runs validations and returns an error if any should fail.
-type OutputsValidatorFunc func(index int, output *SigLockedSingleDeposit) error
+// ValidateOutputs calls it once per output in the order of the outputs, so a validator may keep state over the calls,
+// like OutputsAddrUniqueValidator does. Such validators must be created anew for every set of outputs.
+type OutputsValidatorFunc func(index int, output *SigLockedSingleOutput) error
 
 // OutputsAddrUniqueValidator returns a validator which checks that all addresses are unique.
+// As outputs are validated in their order, the error names the first output which repeats an address
+// together with the index of the output it repeats.
 func OutputsAddrUniqueValidator() OutputsValidatorFunc {
+	trackAddr := addrUniqueTracker(ErrOutputAddrNotUnique)
+	return func(index int, dep *SigLockedSingleOutput) error {
+		return trackAddr(index, dep.Address)
+	}
+}
+
+// addrUniqueTracker returns a func which remembers the address of the output at the given index and returns an error
+// wrapping errOnDuplicate if a previous call already passed in the same address. Addresses are keyed by their
+// serialized form so that addresses of different types never collide.
+func addrUniqueTracker(errOnDuplicate error) func(index int, addr Serializable) error {
 	set := map[string]int{}
-	return func(index int, dep *SigLockedSingleDeposit) error {
-		var b strings.Builder
-		// can't be reduced to one b.Write()
-		switch addr := dep.Address.(type) {
-		case *WOTSAddress:
-			if _, err := b.Write(addr[:]); err != nil {
-				return err
-			}
-		case *Ed25519Address:
-			if _, err := b.Write(addr[:]); err != nil {
-				return err
-			}
+	return func(index int, addr Serializable) error {
+		if addr == nil {
+			return fmt.Errorf("%w: output %d has no address", ErrUnknownAddrType, index)
+		}
+		addrData, err := addr.Serialize(DeSeriModeNoValidation)
+		if err != nil {
+			return fmt.Errorf("unable to serialize address of output %d: %w", index, err)
 		}
-		k := b.String()
+		k := string(addrData)
 		if j, has := set[k]; has {
-			return fmt.Errorf("%w: output %d and %d share the same address", ErrOutputAddrNotUnique, j, index)
+			return fmt.Errorf("%w: output %d and %d share the same address", errOnDuplicate, j, index)
 		}
 		set[k] = index
 		return nil
 	}
 }
 
+// OutputsNonZeroAddressValidator returns a validator which checks that no output deposits onto an all zero address.
+func OutputsNonZeroAddressValidator() OutputsValidatorFunc {
+	return func(index int, dep *SigLockedSingleOutput) error {
+		var addrBytes []byte
+		switch addr := dep.Address.(type) {
+		case *WOTSAddress:
+			addrBytes = addr[:]
+		case *Ed25519Address:
+			addrBytes = addr[:]
+		case *AliasAddress:
+			addrBytes = addr[:]
+		case *NFTAddress:
+			addrBytes = addr[:]
+		default:
+			return fmt.Errorf("%w: output %d has address of type %T", ErrUnknownAddrType, index, addr)
+		}
+		for _, b := range addrBytes {
+			if b != 0 {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: output %d", ErrZeroAddress, index)
+	}
+}
+
 // OutputsDepositAmountValidator returns a validator which checks that:
 //	1. every output deposits more than zero
 //	2. every output deposits less than the total supply
 //	3. the sum of deposits does not exceed the total supply
 // If -1 is passed to the validator func, then the sum is not aggregated over multiple calls.
 func OutputsDepositAmountValidator() OutputsValidatorFunc {
+	outputsValidator, _ := OutputsDepositAmountValidators()
+	return outputsValidator
+}
+
+// OutputsDepositAmountValidators returns an OutputsDepositAmountValidator together with a validator for dust allowance
+// outputs which share the sum of deposits, so that the sum over both kinds of outputs must not exceed the total supply.
+// The dust allowance validator doesn't check the minimum dust allowance amount, see OutputsDustAllowanceValidator.
+func OutputsDepositAmountValidators() (OutputsValidatorFunc, DustAllowanceOutputsValidatorFunc) {
 	var sum uint64
-	return func(index int, dep *SigLockedSingleDeposit) error {
-		if dep.Amount == 0 {
-			return fmt.Errorf("%w: output %d", ErrDepositAmountMustBeGreaterThanZero, index)
-		}
-		if dep.Amount > TokenSupply {
+	addToSum := func(index int, amount uint64) error {
+		if amount > TokenSupply {
 			return fmt.Errorf("%w: output %d", ErrOutputDepositsMoreThanTotalSupply, index)
 		}
-		if sum+dep.Amount > TokenSupply {
+		// checked against the remaining supply so the sum can't overflow
+		if amount > TokenSupply-sum {
 			return fmt.Errorf("%w: output %d", ErrOutputsSumExceedsTotalSupply, index)
 		}
 		if index != -1 {
-			sum += dep.Amount
+			sum += amount
 		}
 		return nil
 	}
+	outputsValidator := func(index int, dep *SigLockedSingleOutput) error {
+		if dep.Amount == 0 {
+			return fmt.Errorf("%w: output %d", ErrDepositAmountMustBeGreaterThanZero, index)
+		}
+		return addToSum(index, dep.Amount)
+	}
+	dustAllowanceValidator := func(index int, dep *SigLockedDustAllowanceOutput) error {
+		return addToSum(index, dep.Amount)
+	}
+	return outputsValidator, dustAllowanceValidator
 }
 
 // supposed to be called with -1 as input in order to be used over multiple calls.
 var outputAmountValidator = OutputsDepositAmountValidator()
 
 // ValidateOutputs validates the outputs by running them against the given OutputsValidatorFunc.
+// For every output all funcs are run before the next output is validated. The first error is returned.
+// Dust allowance outputs are skipped, they are validated via ValidateDustAllowanceOutputs.
 func ValidateOutputs(outputs Serializables, funcs ...OutputsValidatorFunc) error {
 	for i, output := range outputs {
-		dep, ok := output.(*SigLockedSingleDeposit)
+		if _, isDustAllowanceOutput := output.(*SigLockedDustAllowanceOutput); isDustAllowanceOutput {
+			continue
+		}
+		dep, ok := output.(*SigLockedSingleOutput)
 		if !ok {
-			return fmt.Errorf("%w: can only validate on signature locked single deposits", ErrUnknownOutputType)
+			return fmt.Errorf("%w: can only validate on signature locked single outputs", ErrUnknownOutputType)
 		}
 		for _, f := range funcs {
 			if err := f(i, dep); err != nil {
@@ -177,3 +488,139 @@ func ValidateOutputs(outputs Serializables, funcs ...OutputsValidatorFunc) error
 	}
 	return nil
 }
+
+// DustAllowanceOutputsValidatorFunc which given the index of a dust allowance output and the output itself, runs validations
+// and returns an error if any should fail.
+type DustAllowanceOutputsValidatorFunc func(index int, output *SigLockedDustAllowanceOutput) error
+
+// OutputsDustAllowanceValidator returns a validator which checks that:
+//	1. every dust allowance output deposits at least DustAllowanceMinimum
+//	2. every dust allowance output deposits less than the total supply
+//	3. no two dust allowance outputs deposit onto the same address
+// If -1 is passed to the validator func, then the address is not remembered over multiple calls.
+func OutputsDustAllowanceValidator() DustAllowanceOutputsValidatorFunc {
+	trackAddr := addrUniqueTracker(ErrDustAllowanceAddrNotUnique)
+	return func(index int, dep *SigLockedDustAllowanceOutput) error {
+		if dep.Amount < DustAllowanceMinimum {
+			return fmt.Errorf("%w: output %d deposits %d", ErrDustAllowanceAmountTooLow, index, dep.Amount)
+		}
+		if dep.Amount > TokenSupply {
+			return fmt.Errorf("%w: output %d", ErrOutputDepositsMoreThanTotalSupply, index)
+		}
+		if index == -1 {
+			return nil
+		}
+		return trackAddr(index, dep.Address)
+	}
+}
+
+// supposed to be called with -1 as input in order to be used over multiple calls.
+var dustAllowanceAmountValidator = OutputsDustAllowanceValidator()
+
+// ValidateDustAllowanceOutputs validates the dust allowance outputs by running them against the given
+// DustAllowanceOutputsValidatorFunc. Other outputs are skipped, they are validated via ValidateOutputs.
+func ValidateDustAllowanceOutputs(outputs Serializables, funcs ...DustAllowanceOutputsValidatorFunc) error {
+	for i, output := range outputs {
+		dep, ok := output.(*SigLockedDustAllowanceOutput)
+		if !ok {
+			continue
+		}
+		for _, f := range funcs {
+			if err := f(i, dep); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// NewSigLockedSingleOutputToBech32 creates a SigLockedSingleOutput which deposits the given amount
+// onto the address encoded in the given Bech32 string.
+func NewSigLockedSingleOutputToBech32(bech32Addr string, amount uint64) (Serializable, error) {
+	addr, _, err := ParseBech32(bech32Addr)
+	if err != nil {
+		return nil, err
+	}
+	output := &SigLockedSingleOutput{Address: addr, Amount: amount}
+	if err := outputAmountValidator(-1, output); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+type jsonSigLockedSingleOutput struct {
+	Type    uint32          `json:"type"`
+	Address json.RawMessage `json:"address"`
+	Amount  uint64          `json:"amount"`
+}
+
+func (s *SigLockedSingleOutput) MarshalJSON() ([]byte, error) {
+	addrJSON, err := json.Marshal(s.Address)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&jsonSigLockedSingleOutput{Type: uint32(OutputSigLockedSingleOutput), Address: addrJSON, Amount: s.Amount})
+}
+
+func (s *SigLockedSingleOutput) UnmarshalJSON(data []byte) error {
+	jOutput := &jsonSigLockedSingleOutput{}
+	if err := json.Unmarshal(data, jOutput); err != nil {
+		return err
+	}
+	if err := checkJSONType(jOutput.Type, uint32(OutputSigLockedSingleOutput), "signature locked single output"); err != nil {
+		return err
+	}
+	addr, err := unmarshalJSONObject(jOutput.Address, AddressSelector)
+	if err != nil {
+		return fmt.Errorf("unable to decode signature locked single output address from JSON: %w", err)
+	}
+	s.Address = addr
+	s.Amount = jOutput.Amount
+	return nil
+}
+
+func (s *SigLockedDustAllowanceOutput) MarshalJSON() ([]byte, error) {
+	addrJSON, err := json.Marshal(s.Address)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&jsonSigLockedSingleOutput{Type: uint32(OutputSigLockedDustAllowanceOutput), Address: addrJSON, Amount: s.Amount})
+}
+
+func (s *SigLockedDustAllowanceOutput) UnmarshalJSON(data []byte) error {
+	jOutput := &jsonSigLockedSingleOutput{}
+	if err := json.Unmarshal(data, jOutput); err != nil {
+		return err
+	}
+	if err := checkJSONType(jOutput.Type, uint32(OutputSigLockedDustAllowanceOutput), "signature locked dust allowance output"); err != nil {
+		return err
+	}
+	addr, err := unmarshalJSONObject(jOutput.Address, AddressSelector)
+	if err != nil {
+		return fmt.Errorf("unable to decode signature locked dust allowance output address from JSON: %w", err)
+	}
+	s.Address = addr
+	s.Amount = jOutput.Amount
+	return nil
+}
+
+type jsonTreasuryOutput struct {
+	Type   uint32 `json:"type"`
+	Amount uint64 `json:"amount"`
+}
+
+func (t *TreasuryOutput) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonTreasuryOutput{Type: uint32(OutputTreasuryOutput), Amount: t.Amount})
+}
+
+func (t *TreasuryOutput) UnmarshalJSON(data []byte) error {
+	jOutput := &jsonTreasuryOutput{}
+	if err := json.Unmarshal(data, jOutput); err != nil {
+		return err
+	}
+	if err := checkJSONType(jOutput.Type, uint32(OutputTreasuryOutput), "treasury output"); err != nil {
+		return err
+	}
+	t.Amount = jOutput.Amount
+	return nil
+}
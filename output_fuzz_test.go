@@ -0,0 +1,27 @@
+//go:build go1.18
+// +build go1.18
+
+package iota_test
+
+import (
+	"testing"
+
+	"github.com/luca-moser/iota"
+)
+
+func FuzzSigLockedSingleOutputDeserialize(f *testing.F) {
+	_, wotsData := randSigLockedSingleOutput(iota.AddressWOTS)
+	_, edData := randSigLockedSingleOutput(iota.AddressEd25519)
+	fuzzDeserialize(f, func() iota.Serializable { return &iota.SigLockedSingleOutput{} }, wotsData, edData)
+}
+
+func FuzzSigLockedDustAllowanceOutputDeserialize(f *testing.F) {
+	_, data := randSigLockedDustAllowanceOutput(iota.DustAllowanceMinimum)
+	fuzzDeserialize(f, func() iota.Serializable { return &iota.SigLockedDustAllowanceOutput{} }, data)
+}
+
+func FuzzTreasuryOutputDeserialize(f *testing.F) {
+	data, err := (&iota.TreasuryOutput{Amount: 1337}).Serialize(iota.DeSeriModePerformValidation)
+	must(err)
+	fuzzDeserialize(f, func() iota.Serializable { return &iota.TreasuryOutput{} }, data)
+}
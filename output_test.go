@@ -1,7 +1,9 @@
 package iota_test
 
 import (
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/luca-moser/iota"
@@ -13,7 +15,7 @@ func TestOutputSelector(t *testing.T) {
 	assert.True(t, errors.Is(err, iota.ErrUnknownOutputType))
 }
 
-func TestSigLockedSingleDeposit_Deserialize(t *testing.T) {
+func TestSigLockedSingleOutput_Deserialize(t *testing.T) {
 	type test struct {
 		name   string
 		source []byte
@@ -22,30 +24,30 @@ func TestSigLockedSingleDeposit_Deserialize(t *testing.T) {
 	}
 	tests := []test{
 		func() test {
-			dep, depData := randSigLockedSingleDeposit(iota.AddressWOTS)
+			dep, depData := randSigLockedSingleOutput(iota.AddressWOTS)
 			return test{"ok wots", depData, dep, nil}
 		}(),
 		func() test {
-			dep, depData := randSigLockedSingleDeposit(iota.AddressWOTS)
+			dep, depData := randSigLockedSingleOutput(iota.AddressWOTS)
 			return test{"not enough data wots", depData[:5], dep, iota.ErrDeserializationNotEnoughData}
 		}(),
 		func() test {
-			dep, depData := randSigLockedSingleDeposit(iota.AddressEd25519)
+			dep, depData := randSigLockedSingleOutput(iota.AddressEd25519)
 			return test{"ok ed25519", depData, dep, nil}
 		}(),
 		func() test {
-			dep, depData := randSigLockedSingleDeposit(iota.AddressEd25519)
+			dep, depData := randSigLockedSingleOutput(iota.AddressEd25519)
 			return test{"not enough data ed25519", depData[:5], dep, iota.ErrDeserializationNotEnoughData}
 		}(),
 		func() test {
-			dep, depData := randSigLockedSingleDeposit(iota.AddressEd25519)
-			depData[iota.SigLockedSingleDepositAddressOffset] = 100
+			dep, depData := randSigLockedSingleOutput(iota.AddressEd25519)
+			depData[iota.SigLockedSingleOutputAddressOffset] = 100
 			return test{"unknown addr type", depData, dep, iota.ErrUnknownAddrType}
 		}(),
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			dep := &iota.SigLockedSingleDeposit{}
+			dep := &iota.SigLockedSingleOutput{}
 			bytesRead, err := dep.Deserialize(tt.source, iota.DeSeriModePerformValidation)
 			if tt.err != nil {
 				assert.True(t, errors.Is(err, tt.err))
@@ -54,20 +56,21 @@ func TestSigLockedSingleDeposit_Deserialize(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, len(tt.source), bytesRead)
 			assert.EqualValues(t, tt.target, dep)
+			AssertTruncationSafe(t, tt.source, func() iota.Serializable { return &iota.SigLockedSingleOutput{} })
 		})
 	}
 }
 
-func TestSigLockedSingleDeposit_Serialize(t *testing.T) {
+func TestSigLockedSingleOutput_Serialize(t *testing.T) {
 	type test struct {
 		name   string
-		source *iota.SigLockedSingleDeposit
+		source *iota.SigLockedSingleOutput
 		target []byte
 		err    error
 	}
 	tests := []test{
 		func() test {
-			dep, depData := randSigLockedSingleDeposit(iota.AddressEd25519)
+			dep, depData := randSigLockedSingleOutput(iota.AddressEd25519)
 			return test{"ok", dep, depData, nil}
 		}(),
 	}
@@ -97,14 +100,14 @@ func TestOutputsValidatorFunc(t *testing.T) {
 		{
 			"ok addr",
 			args{outputs: []iota.Serializable{
-				&iota.SigLockedSingleDeposit{
+				&iota.SigLockedSingleOutput{
 					Address: func() iota.Serializable {
 						addr, _ := randEd25519Addr()
 						return addr
 					}(),
 					Amount: 0,
 				},
-				&iota.SigLockedSingleDeposit{
+				&iota.SigLockedSingleOutput{
 					Address: func() iota.Serializable {
 						addr, _ := randEd25519Addr()
 						return addr
@@ -116,7 +119,7 @@ func TestOutputsValidatorFunc(t *testing.T) {
 		{
 			"addr not unique",
 			args{outputs: []iota.Serializable{
-				&iota.SigLockedSingleDeposit{
+				&iota.SigLockedSingleOutput{
 					Address: func() iota.Serializable {
 						addr, _ := randEd25519Addr()
 						for i := 0; i < len(addr); i++ {
@@ -126,7 +129,7 @@ func TestOutputsValidatorFunc(t *testing.T) {
 					}(),
 					Amount: 0,
 				},
-				&iota.SigLockedSingleDeposit{
+				&iota.SigLockedSingleOutput{
 					Address: func() iota.Serializable {
 						addr, _ := randEd25519Addr()
 						for i := 0; i < len(addr); i++ {
@@ -138,10 +141,32 @@ func TestOutputsValidatorFunc(t *testing.T) {
 				},
 			}, funcs: []iota.OutputsValidatorFunc{iota.OutputsAddrUniqueValidator()}}, true,
 		},
+		{
+			"ok non zero addr",
+			args{outputs: []iota.Serializable{
+				&iota.SigLockedSingleOutput{
+					Address: func() iota.Serializable {
+						addr, _ := randEd25519Addr()
+						addr[0] = 1
+						return addr
+					}(),
+					Amount: 1,
+				},
+			}, funcs: []iota.OutputsValidatorFunc{iota.OutputsNonZeroAddressValidator()}}, false,
+		},
+		{
+			"zero addr",
+			args{outputs: []iota.Serializable{
+				&iota.SigLockedSingleOutput{
+					Address: &iota.Ed25519Address{},
+					Amount:  1,
+				},
+			}, funcs: []iota.OutputsValidatorFunc{iota.OutputsNonZeroAddressValidator()}}, true,
+		},
 		{
 			"ok amount",
 			args{outputs: []iota.Serializable{
-				&iota.SigLockedSingleDeposit{
+				&iota.SigLockedSingleOutput{
 					Address: nil,
 					Amount:  iota.TokenSupply,
 				},
@@ -150,7 +175,7 @@ func TestOutputsValidatorFunc(t *testing.T) {
 		{
 			"spends more than total supply",
 			args{outputs: []iota.Serializable{
-				&iota.SigLockedSingleDeposit{
+				&iota.SigLockedSingleOutput{
 					Address: nil,
 					Amount:  iota.TokenSupply + 1,
 				},
@@ -159,11 +184,11 @@ func TestOutputsValidatorFunc(t *testing.T) {
 		{
 			"sum more than total supply",
 			args{outputs: []iota.Serializable{
-				&iota.SigLockedSingleDeposit{
+				&iota.SigLockedSingleOutput{
 					Address: nil,
 					Amount:  iota.TokenSupply - 1,
 				},
-				&iota.SigLockedSingleDeposit{
+				&iota.SigLockedSingleOutput{
 					Address: nil,
 					Amount:  iota.TokenSupply - 1,
 				},
@@ -178,3 +203,320 @@ func TestOutputsValidatorFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateOutputs_CustomValidator(t *testing.T) {
+	blockedAddr, _ := randEd25519Addr()
+	errBlockedAddr := errors.New("address is blocked")
+	blockedAddrValidator := func(index int, output *iota.SigLockedSingleOutput) error {
+		if addr, ok := output.Address.(*iota.Ed25519Address); ok && *addr == *blockedAddr {
+			return fmt.Errorf("%w: output %d", errBlockedAddr, index)
+		}
+		return nil
+	}
+
+	ok1, _ := randSigLockedSingleOutput(iota.AddressEd25519)
+	ok2, _ := randSigLockedSingleOutput(iota.AddressEd25519)
+	blocked := &iota.SigLockedSingleOutput{Address: blockedAddr, Amount: 1}
+
+	assert.NoError(t, iota.ValidateOutputs(iota.Serializables{ok1, ok2}, blockedAddrValidator, iota.OutputsAddrUniqueValidator()))
+	err := iota.ValidateOutputs(iota.Serializables{ok1, blocked, ok2}, iota.OutputsAddrUniqueValidator(), blockedAddrValidator)
+	assert.True(t, errors.Is(err, errBlockedAddr))
+	assert.Contains(t, err.Error(), "output 1")
+}
+
+func TestOutputsAddrUniqueValidator_FirstDuplicate(t *testing.T) {
+	addr1, _ := randEd25519Addr()
+	addr2, _ := randEd25519Addr()
+	outputs := iota.Serializables{
+		&iota.SigLockedSingleOutput{Address: addr1, Amount: 1},
+		&iota.SigLockedSingleOutput{Address: addr2, Amount: 1},
+		&iota.SigLockedSingleOutput{Address: addr1, Amount: 1},
+		&iota.SigLockedSingleOutput{Address: addr2, Amount: 1},
+	}
+
+	err := iota.ValidateOutputs(outputs, iota.OutputsAddrUniqueValidator())
+	assert.True(t, errors.Is(err, iota.ErrOutputAddrNotUnique))
+	assert.Contains(t, err.Error(), "output 0 and 2")
+}
+
+func TestOutputsAddrUniqueValidator_AddressTypes(t *testing.T) {
+	aliasAddr, _ := randAliasAddr()
+	nftAddr := &iota.NFTAddress{}
+	copy(nftAddr[:], aliasAddr[:])
+
+	// alias and NFT addresses share the same length, so only their type byte tells them apart
+	outputs := iota.Serializables{
+		&iota.SigLockedSingleOutput{Address: aliasAddr, Amount: 1},
+		&iota.SigLockedSingleOutput{Address: nftAddr, Amount: 1},
+	}
+	assert.NoError(t, iota.ValidateOutputs(outputs, iota.OutputsAddrUniqueValidator()))
+
+	outputs = append(outputs, &iota.SigLockedSingleOutput{Address: nftAddr, Amount: 1})
+	err := iota.ValidateOutputs(outputs, iota.OutputsAddrUniqueValidator())
+	assert.True(t, errors.Is(err, iota.ErrOutputAddrNotUnique), "unexpected error %v", err)
+	assert.Contains(t, err.Error(), "output 1 and 2")
+}
+
+func TestOutputsDepositAmountValidator_Sum(t *testing.T) {
+	outputsWithAmounts := func(amounts ...uint64) iota.Serializables {
+		outputs := make(iota.Serializables, len(amounts))
+		for i, amount := range amounts {
+			outputs[i] = &iota.SigLockedSingleOutput{Amount: amount}
+		}
+		return outputs
+	}
+	tests := []struct {
+		name    string
+		outputs iota.Serializables
+		err     error
+	}{
+		{"ok total supply", outputsWithAmounts(iota.TokenSupply/2, iota.TokenSupply-iota.TokenSupply/2), nil},
+		{"sum exceeds total supply by one", outputsWithAmounts(iota.TokenSupply, 1), iota.ErrOutputsSumExceedsTotalSupply},
+		{"sum exceeds total supply", outputsWithAmounts(iota.TokenSupply/2+1, iota.TokenSupply/2+1), iota.ErrOutputsSumExceedsTotalSupply},
+		{"many outputs exceed total supply", outputsWithAmounts(iota.TokenSupply/3+1, iota.TokenSupply/3+1, iota.TokenSupply/3+1, iota.TokenSupply), iota.ErrOutputsSumExceedsTotalSupply},
+		// would wrap around to 0 if summed naively
+		{"sum overflows uint64", outputsWithAmounts(1<<63, 1<<63), iota.ErrOutputDepositsMoreThanTotalSupply},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := iota.ValidateOutputs(tt.outputs, iota.OutputsDepositAmountValidator())
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "expected %v, got %v", tt.err, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSigLockedSingleOutput_RoundTrip(t *testing.T) {
+	addr, _ := randEd25519Addr()
+	origin := &iota.SigLockedSingleOutput{Address: addr, Amount: 1337}
+
+	data, err := origin.Serialize(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.Len(t, data, iota.SigLockedSingleOutputEd25519AddrBytesSize)
+
+	output, bytesRead, err := iota.DeserializeObject(data, iota.DeSeriModePerformValidation, iota.TypeDenotationByte, iota.OutputSelector)
+	assert.NoError(t, err)
+	assert.Equal(t, len(data), bytesRead)
+	assert.EqualValues(t, origin, output)
+}
+
+func TestSigLockedDustAllowanceOutput_Deserialize(t *testing.T) {
+	type test struct {
+		name   string
+		source []byte
+		target iota.Serializable
+		err    error
+	}
+	tests := []test{
+		func() test {
+			dep, depData := randSigLockedDustAllowanceOutput(iota.DustAllowanceMinimum)
+			return test{"ok", depData, dep, nil}
+		}(),
+		func() test {
+			_, depData := randSigLockedDustAllowanceOutput(iota.DustAllowanceMinimum - 1)
+			return test{"below dust allowance minimum", depData, nil, iota.ErrDustAllowanceAmountTooLow}
+		}(),
+		func() test {
+			_, depData := randSigLockedDustAllowanceOutput(iota.TokenSupply + 1)
+			return test{"more than total supply", depData, nil, iota.ErrOutputDepositsMoreThanTotalSupply}
+		}(),
+		func() test {
+			_, depData := randSigLockedDustAllowanceOutput(iota.DustAllowanceMinimum)
+			return test{"not enough data", depData[:iota.SigLockedDustAllowanceOutputBytesMinSize-1], nil, iota.ErrDeserializationNotEnoughData}
+		}(),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, bytesRead, err := iota.DeserializeObject(tt.source, iota.DeSeriModePerformValidation, iota.TypeDenotationByte, iota.OutputSelector)
+			// must agree with the deserialization
+			bytesValidated, validateErr := iota.ValidateBytes(tt.source, iota.DeSeriModePerformValidation, iota.OutputSelector)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "expected %v, got %v", tt.err, err)
+				assert.True(t, errors.Is(validateErr, tt.err), "expected %v, got %v", tt.err, validateErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NoError(t, validateErr)
+			assert.Len(t, tt.source, bytesRead)
+			assert.Equal(t, bytesRead, bytesValidated)
+			assert.EqualValues(t, tt.target, output)
+			AssertTruncationSafe(t, tt.source, func() iota.Serializable { return &iota.SigLockedDustAllowanceOutput{} })
+		})
+	}
+}
+
+func TestSigLockedDustAllowanceOutput_Serialize(t *testing.T) {
+	type test struct {
+		name   string
+		source *iota.SigLockedDustAllowanceOutput
+		target []byte
+		err    error
+	}
+	tests := []test{
+		func() test {
+			dep, depData := randSigLockedDustAllowanceOutput(iota.DustAllowanceMinimum)
+			return test{"ok", dep, depData, nil}
+		}(),
+		func() test {
+			dep, _ := randSigLockedDustAllowanceOutput(1)
+			return test{"below dust allowance minimum", dep, nil, iota.ErrDustAllowanceAmountTooLow}
+		}(),
+		{"without address", &iota.SigLockedDustAllowanceOutput{Amount: iota.DustAllowanceMinimum}, nil, iota.ErrUnknownAddrType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.source.Serialize(iota.DeSeriModePerformValidation)
+			buf := make([]byte, len(tt.target)+10)
+			bytesWritten, serializeIntoErr := tt.source.SerializeInto(buf, iota.DeSeriModePerformValidation)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "expected %v, got %v", tt.err, err)
+				assert.True(t, errors.Is(serializeIntoErr, tt.err), "expected %v, got %v", tt.err, serializeIntoErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NoError(t, serializeIntoErr)
+			assert.Equal(t, tt.target, data)
+			assert.Equal(t, tt.target, buf[:bytesWritten])
+
+			_, err = tt.source.SerializeInto(buf[:len(tt.target)-1], iota.DeSeriModePerformValidation)
+			assert.True(t, errors.Is(err, iota.ErrSerializationBufferTooSmall), "unexpected error %v", err)
+		})
+	}
+}
+
+func TestOutputsDustAllowanceValidator(t *testing.T) {
+	dep1, _ := randSigLockedDustAllowanceOutput(iota.DustAllowanceMinimum)
+	dep2, _ := randSigLockedDustAllowanceOutput(iota.DustAllowanceMinimum)
+	sameAddrDep := &iota.SigLockedDustAllowanceOutput{Address: dep1.Address, Amount: iota.DustAllowanceMinimum * 2}
+	single, _ := randSigLockedSingleOutput(iota.AddressEd25519)
+	singleToSameAddr := &iota.SigLockedSingleOutput{Address: dep1.Address, Amount: 1}
+	lowDep, _ := randSigLockedDustAllowanceOutput(iota.DustAllowanceMinimum - 1)
+
+	tests := []struct {
+		name    string
+		outputs iota.Serializables
+		err     error
+	}{
+		{"ok", iota.Serializables{dep1, dep2}, nil},
+		{"ok with other outputs to the same address", iota.Serializables{dep1, single, singleToSameAddr}, nil},
+		{"below dust allowance minimum", iota.Serializables{dep1, lowDep}, iota.ErrDustAllowanceAmountTooLow},
+		{"address not unique", iota.Serializables{dep1, dep2, sameAddrDep}, iota.ErrDustAllowanceAddrNotUnique},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := iota.ValidateDustAllowanceOutputs(tt.outputs, iota.OutputsDustAllowanceValidator())
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "expected %v, got %v", tt.err, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSigLockedSingleOutput_AliasAndNFTAddresses(t *testing.T) {
+	aliasAddr, _ := randAliasAddr()
+	nftAddr, _ := randNFTAddr()
+	tests := []struct {
+		name string
+		addr iota.Serializable
+		size int
+	}{
+		{"alias address", aliasAddr, iota.SigLockedSingleOutputAliasAddrBytesSize},
+		{"NFT address", nftAddr, iota.SigLockedSingleOutputNFTAddrBytesSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin := &iota.SigLockedSingleOutput{Address: tt.addr, Amount: 1337}
+			data, err := origin.Serialize(iota.DeSeriModePerformValidation)
+			assert.NoError(t, err)
+			assert.Len(t, data, tt.size)
+
+			output, bytesRead, err := iota.DeserializeObject(data, iota.DeSeriModePerformValidation, iota.TypeDenotationByte, iota.OutputSelector)
+			assert.NoError(t, err)
+			assert.Equal(t, len(data), bytesRead)
+			assert.EqualValues(t, origin, output)
+		})
+	}
+}
+
+func TestTreasuryOutput_RoundTrip(t *testing.T) {
+	origin := &iota.TreasuryOutput{Amount: iota.TokenSupply}
+
+	data, err := origin.Serialize(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.Len(t, data, iota.TreasuryOutputSize)
+
+	output, bytesRead, err := iota.DeserializeObject(data, iota.DeSeriModePerformValidation, iota.TypeDenotationByte, iota.OutputSelector)
+	assert.NoError(t, err)
+	assert.Equal(t, len(data), bytesRead)
+	assert.EqualValues(t, origin, output)
+	AssertTruncationSafe(t, data, func() iota.Serializable { return &iota.TreasuryOutput{} })
+
+	_, err = (&iota.TreasuryOutput{Amount: iota.TokenSupply + 1}).Serialize(iota.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, iota.ErrTreasuryOutputAmountExceedsTotalSupply))
+}
+
+func TestSigLockedSingleOutput_AmountValidation(t *testing.T) {
+	addr, _ := randEd25519Addr()
+	for _, tt := range []struct {
+		name   string
+		amount uint64
+		err    error
+	}{
+		{"zero", 0, iota.ErrDepositAmountMustBeGreaterThanZero},
+		{"more than total supply", iota.TokenSupply + 1, iota.ErrOutputDepositsMoreThanTotalSupply},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			output := &iota.SigLockedSingleOutput{Address: addr, Amount: tt.amount}
+			_, err := output.Serialize(iota.DeSeriModePerformValidation)
+			assert.True(t, errors.Is(err, tt.err))
+
+			data, err := output.Serialize(iota.DeSeriModeNoValidation)
+			assert.NoError(t, err)
+			_, err = (&iota.SigLockedSingleOutput{}).Deserialize(data, iota.DeSeriModePerformValidation)
+			assert.True(t, errors.Is(err, tt.err))
+		})
+	}
+}
+
+func TestNewSigLockedSingleOutputToBech32(t *testing.T) {
+	var edAddr iota.Ed25519Address
+	_, err := hex.Decode(edAddr[:], []byte("efdc112efe262b304bcf379b26c31bad029f616ee3ec4aa6345a366e4c9e43a3"))
+	assert.NoError(t, err)
+
+	type test struct {
+		name   string
+		bech32 string
+		amount uint64
+		target iota.Serializable
+		err    error
+	}
+	tests := []test{
+		{"ok", "iota1q8hacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6x6h3a83", 1337, &iota.SigLockedSingleOutput{Address: &edAddr, Amount: 1337}, nil},
+		{"invalid checksum", "iota1q8hacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6x6h3a84", 1337, nil, iota.ErrBech32ChecksumMismatch},
+		{"not bech32", "iota", 1337, nil, iota.ErrInvalidBech32},
+		{"zero amount", "iota1q8hacyfwlcnzkvzteumekfkrrwks98mpdm37cj4xx3drvmjvnep6x6h3a83", 0, nil, iota.ErrDepositAmountMustBeGreaterThanZero},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := iota.NewSigLockedSingleOutputToBech32(tt.bech32, tt.amount)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, tt.target, output)
+		})
+	}
+}
+
+func TestOutputsNonZeroAddressValidator(t *testing.T) {
+	err := iota.ValidateOutputs(iota.Serializables{
+		&iota.SigLockedSingleOutput{Address: &iota.WOTSAddress{}, Amount: 1},
+	}, iota.OutputsNonZeroAddressValidator())
+	assert.True(t, errors.Is(err, iota.ErrZeroAddress))
+}
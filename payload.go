@@ -7,42 +7,27 @@ import (
 
 // ParsePayload parses a payload out of the given data.
 // It returns the amount of bytes read from data. If the payload length is 0, then
-// the returned Serializable is nil.
-func ParsePayload(data []byte, deSeriMode DeSerializationMode) (Serializable, int, error) {
-	if deSeriMode.HasMode(DeSeriModePerformValidation) {
-		if len(data) < PayloadLengthByteSize {
-			return nil, 0, fmt.Errorf("%w: data is smaller than payload length denotation", ErrDeserializationNotEnoughData)
-		}
-	}
-
-	// read length
-	payloadLength := binary.LittleEndian.Uint32(data)
-	data = data[PayloadLengthByteSize:]
-
-	if payloadLength == 0 {
+// the returned Serializable is nil. If maxPayloadLength is not 0, the payload length
+// must not exceed it when validation is performed.
+func ParsePayload(data []byte, deSeriMode DeSerializationMode, maxPayloadLength uint32) (Serializable, int, error) {
+	payloadLength, err := readPayloadLength(data, deSeriMode, maxPayloadLength)
+	switch {
+	case err != nil:
+		return nil, 0, err
+	case payloadLength == 0:
 		return nil, PayloadLengthByteSize, nil
 	}
-
-	if deSeriMode.HasMode(DeSeriModePerformValidation) {
-		// TODO: check max payload length
-
-		if len(data) < MinPayloadByteSize {
-			return nil, 0, fmt.Errorf("%w: payload data is smaller than min. required length %d", ErrDeserializationNotEnoughData, MinPayloadByteSize)
-		}
-
-		if len(data) < int(payloadLength) {
-			return nil, 0, fmt.Errorf("%w: payload length denotes more bytes than are available", ErrDeserializationNotEnoughData)
-		}
-	}
+	data = data[PayloadLengthByteSize:]
 
 	payload, err := PayloadSelector(binary.LittleEndian.Uint32(data))
 	if err != nil {
 		return nil, 0, err
 	}
 
-	payloadBytesConsumed, err := payload.Deserialize(data, deSeriMode)
+	// the payload must not read beyond its denoted length
+	payloadBytesConsumed, err := payload.Deserialize(data[:payloadLength], deSeriMode)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, shiftDeserializationError(PayloadLengthByteSize, err, "")
 	}
 
 	if payloadBytesConsumed != int(payloadLength) {
@@ -51,3 +36,54 @@ func ParsePayload(data []byte, deSeriMode DeSerializationMode) (Serializable, in
 
 	return payload, UInt32ByteSize + payloadBytesConsumed, nil
 }
+
+// validatePayloadBytes is the BytesValidator counterpart of ParsePayload.
+func validatePayloadBytes(data []byte, deSeriMode DeSerializationMode, maxPayloadLength uint32) (int, error) {
+	payloadLength, err := readPayloadLength(data, deSeriMode, maxPayloadLength)
+	switch {
+	case err != nil:
+		return 0, err
+	case payloadLength == 0:
+		return PayloadLengthByteSize, nil
+	}
+	data = data[PayloadLengthByteSize:]
+
+	payloadBytesConsumed, err := validateObjectBytes(data[:payloadLength], deSeriMode, TypeDenotationUint32, PayloadSelector)
+	if err != nil {
+		return 0, err
+	}
+
+	if payloadBytesConsumed != int(payloadLength) {
+		return 0, fmt.Errorf("%w: denoted payload length (%d) doesn't equal the size of the payload (%d)", ErrInvalidBytes, payloadLength, payloadBytesConsumed)
+	}
+
+	return UInt32ByteSize + payloadBytesConsumed, nil
+}
+
+// readPayloadLength reads the payload length denotation and checks whether data holds the denoted amount of bytes.
+func readPayloadLength(data []byte, deSeriMode DeSerializationMode, maxPayloadLength uint32) (uint32, error) {
+	if len(data) < PayloadLengthByteSize {
+		return 0, fmt.Errorf("%w: data is smaller than payload length denotation", ErrDeserializationNotEnoughData)
+	}
+
+	payloadLength := binary.LittleEndian.Uint32(data)
+	data = data[PayloadLengthByteSize:]
+
+	if payloadLength == 0 {
+		return 0, nil
+	}
+
+	if deSeriMode.HasMode(DeSeriModePerformValidation) && maxPayloadLength != 0 && payloadLength > maxPayloadLength {
+		return 0, fmt.Errorf("%w: payload length is %d but max is %d", ErrPayloadTooLarge, payloadLength, maxPayloadLength)
+	}
+
+	if len(data) < MinPayloadByteSize {
+		return 0, fmt.Errorf("%w: payload data is smaller than min. required length %d", ErrDeserializationNotEnoughData, MinPayloadByteSize)
+	}
+
+	if len(data) < int(payloadLength) {
+		return 0, fmt.Errorf("%w: payload length denotes more bytes than are available", ErrDeserializationNotEnoughData)
+	}
+
+	return payloadLength, nil
+}
@@ -0,0 +1,175 @@
+package iota
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// The amount of trits a Curl-P-81 hash consists of.
+	curlHashLength = 243
+	// The amount of trits of the Curl-P-81 state.
+	curlStateLength = 3 * curlHashLength
+	// The amount of rounds of the Curl-P-81 transformation.
+	curlRounds = 81
+	// The amount of trits a byte is encoded to by b1t6.
+	tritsPerByte = 6
+	// The amount of trits the b1t6 encoded PoW digest occupies.
+	powDigestTritsLength = blake2b.Size256 * tritsPerByte
+	// The amount of trits the b1t6 encoded nonce occupies.
+	powNonceTritsLength = UInt64ByteSize * tritsPerByte
+	// The amount of nonces a PoW worker tries between checking whether its context is done.
+	ctxCheckInterval = 1024
+)
+
+var (
+	curlTruthTable = [11]int8{1, 0, -1, 2, 1, -1, 0, 2, -1, 1, 0}
+	curlIndices    [curlStateLength + 1]int
+)
+
+func init() {
+	for i := 0; i < curlStateLength; i++ {
+		if curlIndices[i] < 365 {
+			curlIndices[i+1] = curlIndices[i] + 364
+			continue
+		}
+		curlIndices[i+1] = curlIndices[i] - 365
+	}
+}
+
+// curlP81Hash computes the Curl-P-81 hash of exactly one hash length of trits.
+func curlP81Hash(trits *[curlHashLength]int8) [curlHashLength]int8 {
+	var state, tmp [curlStateLength]int8
+	copy(state[:], trits[:])
+	for r := 0; r < curlRounds; r++ {
+		tmp = state
+		for i := 0; i < curlStateLength; i++ {
+			state[i] = curlTruthTable[tmp[curlIndices[i]]+(tmp[curlIndices[i+1]]<<2)+5]
+		}
+	}
+	var hash [curlHashLength]int8
+	copy(hash[:], state[:curlHashLength])
+	return hash
+}
+
+// b1t6Encode encodes every byte of src as its two balanced trytes into dst, which must hold len(src)*6 trits.
+func b1t6Encode(dst []int8, src []byte) {
+	for i, b := range src {
+		v := int(int8(b)) + 13*27 + 13
+		low, high := v%27-13, v/27-13
+		putTryte(dst[i*tritsPerByte:], low)
+		putTryte(dst[i*tritsPerByte+3:], high)
+	}
+}
+
+// putTryte writes the balanced tryte value v (-13 to 13) as three trits into dst.
+func putTryte(dst []int8, v int) {
+	v += 13
+	for i := 0; i < 3; i++ {
+		dst[i] = int8(v%3 - 1)
+		v /= 3
+	}
+}
+
+// trailingZeros returns the amount of trailing zero trits of the given hash.
+func trailingZeros(hash *[curlHashLength]int8) int {
+	var zeros int
+	for i := curlHashLength - 1; i >= 0 && hash[i] == 0; i-- {
+		zeros++
+	}
+	return zeros
+}
+
+// powTrits returns the trits hashed for PoW: the b1t6 encoded BLAKE2b-256 digest of the message without its nonce,
+// followed by the b1t6 encoded nonce and zero padding. The nonce trits start at powDigestTritsLength.
+func powTrits(msgWithoutNonce []byte) [curlHashLength]int8 {
+	var trits [curlHashLength]int8
+	digest := blake2b.Sum256(msgWithoutNonce)
+	b1t6Encode(trits[:powDigestTritsLength], digest[:])
+	return trits
+}
+
+// setNonceTrits b1t6 encodes the given nonce into trits.
+func setNonceTrits(trits *[curlHashLength]int8, nonce uint64) {
+	var nonceBytes [UInt64ByteSize]byte
+	binary.LittleEndian.PutUint64(nonceBytes[:], nonce)
+	b1t6Encode(trits[powDigestTritsLength:powDigestTritsLength+powNonceTritsLength], nonceBytes[:])
+}
+
+// PoWScore computes the PoW score of the serialized message, whose last 8 bytes must be its nonce:
+// 3 to the power of the trailing zero trits of the Curl-P-81 hash of the PoW trits, divided by the message length.
+// Data too short to hold a nonce has a score of 0.
+func PoWScore(msgBytes []byte) float64 {
+	if len(msgBytes) < UInt64ByteSize {
+		return 0
+	}
+	nonceOffset := len(msgBytes) - UInt64ByteSize
+	trits := powTrits(msgBytes[:nonceOffset])
+	setNonceTrits(&trits, binary.LittleEndian.Uint64(msgBytes[nonceOffset:]))
+	hash := curlP81Hash(&trits)
+	return math.Pow(3, float64(trailingZeros(&hash))) / float64(len(msgBytes))
+}
+
+// PoWScore computes the PoW score of the message.
+func (m *Message) PoWScore() (float64, error) {
+	data, err := m.Serialize(DeSeriModeNoValidation)
+	if err != nil {
+		return 0, fmt.Errorf("unable to compute PoW score: %w", err)
+	}
+	return PoWScore(data), nil
+}
+
+// DoPoW searches a nonce for which the PoW score of the message reaches targetScore and sets it as the message's Nonce.
+// The search is split across parallelism goroutines, runtime.NumCPU() are used if parallelism isn't positive.
+// If ctx is done before a nonce is found, ctx.Err() is returned and the Nonce is left untouched.
+func (m *Message) DoPoW(ctx context.Context, targetScore float64, parallelism int) error {
+	data, err := m.Serialize(DeSeriModeNoValidation)
+	if err != nil {
+		return fmt.Errorf("unable to do PoW: %w", err)
+	}
+	if parallelism <= 0 {
+		parallelism = runtime.NumCPU()
+	}
+
+	// the score only depends on the trailing zeros, so the target translates to a minimum of them
+	var targetZeros int
+	if targetScore > 0 {
+		targetZeros = int(math.Max(0, math.Ceil(math.Log(targetScore*float64(len(data)))/math.Log(3))))
+	}
+	trits := powTrits(data[:len(data)-UInt64ByteSize])
+
+	var found uint32
+	var nonce uint64
+	var wg sync.WaitGroup
+	for worker := 0; worker < parallelism; worker++ {
+		wg.Add(1)
+		go func(workerTrits [curlHashLength]int8, candidate uint64) {
+			defer wg.Done()
+			for i := 0; atomic.LoadUint32(&found) == 0; i, candidate = i+1, candidate+uint64(parallelism) {
+				if i%ctxCheckInterval == 0 && ctx.Err() != nil {
+					return
+				}
+				setNonceTrits(&workerTrits, candidate)
+				hash := curlP81Hash(&workerTrits)
+				if trailingZeros(&hash) >= targetZeros && atomic.CompareAndSwapUint32(&found, 0, 1) {
+					nonce = candidate
+					return
+				}
+			}
+		}(trits, uint64(worker))
+	}
+	wg.Wait()
+
+	if atomic.LoadUint32(&found) == 0 {
+		return ctx.Err()
+	}
+	m.Nonce = nonce
+	return nil
+}
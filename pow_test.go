@@ -0,0 +1,65 @@
+package iota_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/luca-moser/iota"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoWScore(t *testing.T) {
+	// the score test vectors of the reference Go implementation (iotaledger/iota.go, pow package),
+	// where the last 8 bytes are the nonce and the preceding bytes the data hashed for PoW
+	tests := []struct {
+		name          string
+		msgBytes      []byte
+		expectedScore float64
+	}{
+		{"zero nonce without data", []byte{0, 0, 0, 0, 0, 0, 0, 0}, math.Pow(3, 1) / 8},
+		{"10 trailing zeros", []byte{203, 124, 2, 0, 0, 0, 0, 0}, math.Pow(3, 10) / 8},
+		{"14 trailing zeros", []byte{65, 235, 119, 85, 85, 85, 85, 85}, math.Pow(3, 14) / 8},
+		{"zero nonce with data", bytes.Repeat([]byte{0}, 10000), math.Pow(3, 0) / 10000},
+		{"too short for a nonce", []byte{0, 0, 0, 0, 0, 0, 0}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedScore, iota.PoWScore(tt.msgBytes))
+		})
+	}
+}
+
+func TestMessage_PoWScore(t *testing.T) {
+	msg, msgBytes := randMessage(iota.IndexationPayloadID)
+	score, err := msg.PoWScore()
+	assert.NoError(t, err)
+	assert.Equal(t, iota.PoWScore(msgBytes), score)
+}
+
+func TestMessage_DoPoW(t *testing.T) {
+	const targetScore = 2
+	msg, _ := randMessage(iota.IndexationPayloadID)
+
+	assert.NoError(t, msg.DoPoW(context.Background(), targetScore, 2))
+	score, err := msg.PoWScore()
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, score, float64(targetScore))
+
+	// the found nonce still yields a valid message
+	_, err = msg.Serialize(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+}
+
+func TestMessage_DoPoWCanceled(t *testing.T) {
+	msg, _ := randMessage(0)
+	nonce := msg.Nonce
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := msg.DoPoW(ctx, 1e100, 2)
+	assert.True(t, errors.Is(err, context.Canceled), "unexpected error %v", err)
+	assert.Equal(t, nonce, msg.Nonce)
+}
@@ -0,0 +1,243 @@
+package iota
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	ReceiptPayloadID uint32 = 3
+
+	// The length of a tail transaction hash of the legacy network.
+	LegacyTailTransactionHashLength = 49
+	// tail transaction hash + Ed25519 address + deposit
+	MigratedFundsEntryMinSize = LegacyTailTransactionHashLength + Ed25519AddressSerializedBytesSize + UInt64ByteSize
+	// type + migrated at + final + funds count + treasury transaction length
+	ReceiptPayloadMinSize = TypeDenotationByteSize + UInt32ByteSize + OneByte + StructArrayLengthByteSize + PayloadLengthByteSize
+)
+
+var (
+	ErrReceiptFundsOrderViolatesLexicalOrder  = errors.New("receipt migrated funds must be in their lexical order (byte wise)")
+	ErrReceiptFundsSumExceedsTotalSupply      = errors.New("receipt migrated funds sum exceeds the total supply")
+	ErrReceiptMustContainATreasuryTransaction = errors.New("receipt must contain a treasury transaction")
+	ErrReceiptFinalFlagInvalid                = errors.New("receipt final flag must be either 0 or 1")
+
+	receiptFundsArrayRules = ArrayRules{
+		ElementBytesLexicalOrder:    true,
+		ElementBytesLexicalOrderErr: ErrReceiptFundsOrderViolatesLexicalOrder,
+		MinElementSize:              MigratedFundsEntryMinSize,
+	}
+)
+
+// migratedFundsEntrySelector implements SerializableSelectorFunc for the untyped migrated funds entries.
+func migratedFundsEntrySelector(uint32) (Serializable, error) {
+	return &MigratedFundsEntry{}, nil
+}
+
+// MigratedFundsEntry are funds which were migrated from the legacy network.
+type MigratedFundsEntry struct {
+	// The tail transaction hash of the migration bundle.
+	TailTransactionHash [LegacyTailTransactionHashLength]byte `json:"tail_transaction_hash"`
+	// The target address of the migrated funds.
+	Address Serializable `json:"address"`
+	// The amount of migrated funds.
+	Deposit uint64 `json:"deposit"`
+}
+
+func (m *MigratedFundsEntry) Deserialize(data []byte, deSeriMode DeSerializationMode) (int, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := checkMinByteLength(MigratedFundsEntryMinSize, len(data)); err != nil {
+			return 0, fmt.Errorf("invalid migrated funds entry bytes: %w", err)
+		}
+	}
+
+	return NewDeserializer(data).
+		ReadBytes(m.TailTransactionHash[:], "migrated funds entry tail transaction hash").
+		ReadObject(&m.Address, deSeriMode, TypeDenotationByte, AddressSelector, "migrated funds entry address").
+		ReadNum(&m.Deposit, "migrated funds entry deposit").
+		Done()
+}
+
+func (m *MigratedFundsEntry) Serialize(deSeriMode DeSerializationMode) ([]byte, error) {
+	return NewSerializer().
+		WriteBytes(m.TailTransactionHash[:], "migrated funds entry tail transaction hash").
+		WriteObject(m.Address, deSeriMode, "migrated funds entry address").
+		WriteNum(m.Deposit, "migrated funds entry deposit").
+		Serialize()
+}
+
+// ReceiptPayload is a listing of funds which were migrated from the legacy network at a given milestone.
+type ReceiptPayload struct {
+	// The index of the legacy milestone at which the funds were migrated.
+	MigratedAt uint32 `json:"migrated_at"`
+	// Whether this receipt is the last one for the given legacy milestone.
+	Final bool `json:"final"`
+	// The migrated funds, sorted lexically by their serialized form.
+	Funds Serializables `json:"funds"`
+	// The treasury transaction moving the migrated funds out of the treasury.
+	Transaction Serializable `json:"transaction"`
+}
+
+func (r *ReceiptPayload) Deserialize(data []byte, deSeriMode DeSerializationMode) (int, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := checkMinByteLength(ReceiptPayloadMinSize, len(data)); err != nil {
+			return 0, err
+		}
+		if err := checkType(data, ReceiptPayloadID); err != nil {
+			return 0, fmt.Errorf("unable to deserialize receipt payload: %w", err)
+		}
+	}
+
+	var final byte
+	return NewDeserializer(data).
+		Skip(TypeDenotationByteSize, "receipt payload type").
+		ReadNum(&r.MigratedAt, "receipt migrated at index").
+		ReadNum(&final, "receipt final flag").
+		AbortIf(func() error {
+			if deSeriMode.HasMode(DeSeriModePerformValidation) && final > 1 {
+				return fmt.Errorf("%w: is %d", ErrReceiptFinalFlagInvalid, final)
+			}
+			r.Final = final != 0
+			return nil
+		}).
+		ReadSliceOfObjects(&r.Funds, deSeriMode, TypeDenotationNone, migratedFundsEntrySelector, &receiptFundsArrayRules, "receipt migrated funds").
+		ReadPayload(&r.Transaction, deSeriMode, 0, "receipt treasury transaction").
+		AbortIf(func() error {
+			if deSeriMode.HasMode(DeSeriModePerformValidation) {
+				return r.validate()
+			}
+			return nil
+		}).
+		Done()
+}
+
+func (r *ReceiptPayload) Serialize(deSeriMode DeSerializationMode) ([]byte, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := r.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	var final byte
+	if r.Final {
+		final = 1
+	}
+	return NewSerializer().
+		WriteNum(ReceiptPayloadID, "receipt payload type").
+		WriteNum(r.MigratedAt, "receipt migrated at index").
+		WriteNum(final, "receipt final flag").
+		WriteSliceOfObjects(r.Funds, deSeriMode, receiptFundsArrayRules.lexicalOrderValidatorFor(deSeriMode), "receipt migrated funds").
+		WritePayload(r.Transaction, deSeriMode, "receipt treasury transaction").
+		Serialize()
+}
+
+// WriteTo serializes the ReceiptPayload with WriteToMode and writes it to w, implementing io.WriterTo.
+func (r *ReceiptPayload) WriteTo(w io.Writer) (int64, error) {
+	return writeSerializableTo(w, r)
+}
+
+// validate checks that the receipt holds a treasury transaction and that the migrated funds don't exceed the total supply.
+func (r *ReceiptPayload) validate() error {
+	if _, ok := r.Transaction.(*TreasuryTransaction); !ok {
+		return fmt.Errorf("%w: is %T", ErrReceiptMustContainATreasuryTransaction, r.Transaction)
+	}
+	var sum uint64
+	for i, seri := range r.Funds {
+		entry, ok := seri.(*MigratedFundsEntry)
+		if !ok {
+			return fmt.Errorf("%w: migrated funds entry %d is %T", ErrInvalidBytes, i, seri)
+		}
+		// checked against the remaining supply so the sum can't overflow
+		if entry.Deposit > TokenSupply-sum {
+			return fmt.Errorf("%w: at migrated funds entry %d", ErrReceiptFundsSumExceedsTotalSupply, i)
+		}
+		sum += entry.Deposit
+	}
+	return nil
+}
+
+type jsonMigratedFundsEntry struct {
+	TailTransactionHash string          `json:"tail_transaction_hash"`
+	Address             json.RawMessage `json:"address"`
+	Deposit             uint64          `json:"deposit"`
+}
+
+func (m *MigratedFundsEntry) MarshalJSON() ([]byte, error) {
+	addrJSON, err := json.Marshal(m.Address)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&jsonMigratedFundsEntry{
+		TailTransactionHash: hex.EncodeToString(m.TailTransactionHash[:]),
+		Address:             addrJSON,
+		Deposit:             m.Deposit,
+	})
+}
+
+func (m *MigratedFundsEntry) UnmarshalJSON(data []byte) error {
+	jEntry := &jsonMigratedFundsEntry{}
+	if err := json.Unmarshal(data, jEntry); err != nil {
+		return err
+	}
+	if err := decodeHexInto(m.TailTransactionHash[:], jEntry.TailTransactionHash, "migrated funds entry tail transaction hash"); err != nil {
+		return err
+	}
+	addr, err := unmarshalJSONObject(jEntry.Address, AddressSelector)
+	if err != nil {
+		return fmt.Errorf("unable to decode migrated funds entry address from JSON: %w", err)
+	}
+	m.Address, m.Deposit = addr, jEntry.Deposit
+	return nil
+}
+
+type jsonReceiptPayload struct {
+	Type        uint32                `json:"type"`
+	MigratedAt  uint32                `json:"migrated_at"`
+	Final       bool                  `json:"final"`
+	Funds       []*MigratedFundsEntry `json:"funds"`
+	Transaction json.RawMessage       `json:"transaction"`
+}
+
+func (r *ReceiptPayload) MarshalJSON() ([]byte, error) {
+	jReceipt := &jsonReceiptPayload{
+		Type:       ReceiptPayloadID,
+		MigratedAt: r.MigratedAt,
+		Final:      r.Final,
+		Funds:      make([]*MigratedFundsEntry, len(r.Funds)),
+	}
+	for i, seri := range r.Funds {
+		entry, ok := seri.(*MigratedFundsEntry)
+		if !ok {
+			return nil, fmt.Errorf("%w: migrated funds entry %d is %T", ErrInvalidBytes, i, seri)
+		}
+		jReceipt.Funds[i] = entry
+	}
+	var err error
+	if jReceipt.Transaction, err = json.Marshal(r.Transaction); err != nil {
+		return nil, err
+	}
+	return json.Marshal(jReceipt)
+}
+
+func (r *ReceiptPayload) UnmarshalJSON(data []byte) error {
+	jReceipt := &jsonReceiptPayload{}
+	if err := json.Unmarshal(data, jReceipt); err != nil {
+		return err
+	}
+	if err := checkJSONType(jReceipt.Type, ReceiptPayloadID, "receipt payload"); err != nil {
+		return err
+	}
+	var funds Serializables
+	for _, entry := range jReceipt.Funds {
+		funds = append(funds, entry)
+	}
+	tx, err := unmarshalJSONPayload(jReceipt.Transaction, "receipt treasury transaction")
+	if err != nil {
+		return err
+	}
+	r.MigratedAt, r.Final, r.Funds, r.Transaction = jReceipt.MigratedAt, jReceipt.Final, funds, tx
+	return nil
+}
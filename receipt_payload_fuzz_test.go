@@ -0,0 +1,15 @@
+//go:build go1.18
+// +build go1.18
+
+package iota_test
+
+import (
+	"testing"
+
+	"github.com/luca-moser/iota"
+)
+
+func FuzzReceiptPayloadDeserialize(f *testing.F) {
+	_, data := randReceiptPayload(3)
+	fuzzDeserialize(f, func() iota.Serializable { return &iota.ReceiptPayload{} }, data)
+}
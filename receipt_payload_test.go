@@ -0,0 +1,119 @@
+package iota_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/luca-moser/iota"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiptPayload_Deserialize(t *testing.T) {
+	type test struct {
+		name   string
+		source []byte
+		target iota.Serializable
+		err    error
+	}
+	tests := []test{
+		func() test {
+			receipt, receiptData := randReceiptPayload(5)
+			return test{"ok", receiptData, receipt, nil}
+		}(),
+		func() test {
+			receipt, receiptData := randReceiptPayload(0)
+			return test{"ok without funds", receiptData, receipt, nil}
+		}(),
+		func() test {
+			receipt, _ := randReceiptPayload(2)
+			receipt.Funds[0], receipt.Funds[1] = receipt.Funds[1], receipt.Funds[0]
+			receiptData, err := receipt.Serialize(iota.DeSeriModeNoValidation)
+			assert.NoError(t, err)
+			return test{"funds not in lexical order", receiptData, nil, iota.ErrReceiptFundsOrderViolatesLexicalOrder}
+		}(),
+		func() test {
+			receipt, _ := randReceiptPayload(2)
+			receipt.Funds[0].(*iota.MigratedFundsEntry).Deposit = iota.TokenSupply
+			receiptData, err := receipt.Serialize(iota.DeSeriModeNoValidation)
+			assert.NoError(t, err)
+			return test{"funds sum exceeds total supply", receiptData, nil, iota.ErrReceiptFundsSumExceedsTotalSupply}
+		}(),
+		func() test {
+			receipt, _ := randReceiptPayload(1)
+			receipt.Transaction, _ = randIndexationPayload()
+			receiptData, err := receipt.Serialize(iota.DeSeriModeNoValidation)
+			assert.NoError(t, err)
+			return test{"not a treasury transaction", receiptData, nil, iota.ErrReceiptMustContainATreasuryTransaction}
+		}(),
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receipt := &iota.ReceiptPayload{}
+			bytesRead, err := receipt.Deserialize(tt.source, fullValidation)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "unexpected error %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, len(tt.source), bytesRead)
+			assert.EqualValues(t, tt.target, receipt)
+			AssertTruncationSafe(t, tt.source, func() iota.Serializable { return &iota.ReceiptPayload{} })
+		})
+	}
+}
+
+func TestReceiptPayload_Serialize(t *testing.T) {
+	type test struct {
+		name   string
+		source *iota.ReceiptPayload
+		target []byte
+		err    error
+	}
+	tests := []test{
+		func() test {
+			receipt, receiptData := randReceiptPayload(5)
+			return test{"ok", receipt, receiptData, nil}
+		}(),
+		func() test {
+			receipt, _ := randReceiptPayload(3)
+			receipt.Funds[1].(*iota.MigratedFundsEntry).Deposit = iota.TokenSupply/2 + 1
+			receipt.Funds[2].(*iota.MigratedFundsEntry).Deposit = iota.TokenSupply/2 + 1
+			return test{"funds sum exceeds total supply", receipt, nil, iota.ErrReceiptFundsSumExceedsTotalSupply}
+		}(),
+		func() test {
+			receipt, _ := randReceiptPayload(2)
+			// would wrap around to a small sum without the overflow check
+			receipt.Funds[0].(*iota.MigratedFundsEntry).Deposit = ^uint64(0)
+			receipt.Funds[1].(*iota.MigratedFundsEntry).Deposit = 2
+			return test{"funds sum overflows", receipt, nil, iota.ErrReceiptFundsSumExceedsTotalSupply}
+		}(),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiptData, err := tt.source.Serialize(iota.DeSeriModePerformValidation)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "unexpected error %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.target, receiptData)
+		})
+	}
+}
+
+func TestReceiptPayload_PayloadRoundTrip(t *testing.T) {
+	receipt, receiptData := randReceiptPayload(3)
+	msg := &iota.Message{Parents: randSortedParents(2), Payload: receipt}
+	msgData, err := msg.Serialize(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+
+	msgDeSeri := &iota.Message{}
+	_, err = msgDeSeri.Deserialize(msgData, iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.EqualValues(t, msg, msgDeSeri)
+
+	payloadBytesRead, err := iota.ValidateBytes(receiptData, iota.DeSeriModePerformValidation, iota.PayloadSelector)
+	assert.NoError(t, err)
+	assert.Equal(t, len(receiptData), payloadBytesRead)
+}
@@ -3,7 +3,13 @@ package iota
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
 )
 
 // Serializable is something which knows how to serialize/deserialize itself from/into bytes.
@@ -19,13 +25,152 @@ type Serializable interface {
 	Serialize(deSeriMode DeSerializationMode) ([]byte, error)
 }
 
+// BytesValidator is implemented by Serializables which can check the consistency of their serialized form
+// without deserializing it into an object.
+type BytesValidator interface {
+	// ValidateBytes walks the serialized form at the beginning of data and returns the amount of bytes it occupies.
+	// During the walk additional validation may be performed if the given modes are given.
+	ValidateBytes(data []byte, deSeriMode DeSerializationMode) (int, error)
+}
+
+// BufferSerializable is implemented by Serializables which can serialize themselves into a caller supplied buffer,
+// which allows to reuse a scratch buffer over many serializations instead of allocating a new slice each time.
+type BufferSerializable interface {
+	// SerializeInto writes the serialized form into buf and returns the amount of bytes written.
+	// An error wrapping ErrSerializationBufferTooSmall is returned if buf can't hold the serialized form.
+	// During serialization additional validation may be performed if the given modes are given.
+	SerializeInto(buf []byte, deSeriMode DeSerializationMode) (int, error)
+}
+
+// SizeHinter is implemented by Serializables which know the length of their serialized form without serializing.
+type SizeHinter interface {
+	// SerializedSize returns the amount of bytes the serialized form occupies,
+	// or 0 if it can't be determined, for example because of an unknown nested type.
+	SerializedSize() int
+}
+
+// SerializeInto serializes the given Serializable into buf and returns the amount of bytes written.
+// Serializables which don't implement BufferSerializable are serialized via Serialize and copied into buf.
+func SerializeInto(seri Serializable, buf []byte, deSeriMode DeSerializationMode) (int, error) {
+	if bufSeri, ok := seri.(BufferSerializable); ok {
+		return bufSeri.SerializeInto(buf, deSeriMode)
+	}
+	seriBytes, err := seri.Serialize(deSeriMode)
+	if err != nil {
+		return 0, err
+	}
+	if err := checkSerializationBufferSize(len(seriBytes), len(buf)); err != nil {
+		return 0, err
+	}
+	return copy(buf, seriBytes), nil
+}
+
+// serializeSliceOfObjectsInto writes the given Serializables prefixed by their uint16 count into buf.
+// Every element must start with a type byte resolvable by serSel. An optional LexicalOrderFunc can be passed in
+// to check the order of the serialized elements.
+func serializeSliceOfObjectsInto(seris Serializables, buf []byte, deSeriMode DeSerializationMode, serSel SerializableSelectorFunc, lexicalOrderValidator LexicalOrderFunc, errCtx string) (int, error) {
+	if err := checkSerializationBufferSize(StructArrayLengthByteSize, len(buf)); err != nil {
+		return 0, fmt.Errorf("unable to serialize %s: %w", errCtx, err)
+	}
+	binary.LittleEndian.PutUint16(buf, uint16(len(seris)))
+	offset := StructArrayLengthByteSize
+	for i, seri := range seris {
+		seriBytesWritten, err := SerializeInto(seri, buf[offset:], deSeriMode)
+		if err != nil {
+			return 0, fmt.Errorf("unable to serialize %s at index %d: %w", errCtx, i, err)
+		}
+		seriBytes := buf[offset : offset+seriBytesWritten]
+		if serSel != nil {
+			if err := checkSerializedTypeByte(seriBytes, serSel); err != nil {
+				return 0, fmt.Errorf("unable to serialize %s at index %d: %w", errCtx, i, err)
+			}
+		}
+		if lexicalOrderValidator != nil {
+			if err := lexicalOrderValidator(i, seriBytes); err != nil {
+				return 0, err
+			}
+		}
+		offset += seriBytesWritten
+	}
+	return offset, nil
+}
+
+// typeByteValidator returns a LexicalOrderFunc which checks that every serialized element starts with a type byte
+// resolvable by serSel before handing it to the optional lexicalOrderValidator.
+func typeByteValidator(serSel SerializableSelectorFunc, lexicalOrderValidator LexicalOrderFunc) LexicalOrderFunc {
+	return func(index int, seriBytes []byte) error {
+		if err := checkSerializedTypeByte(seriBytes, serSel); err != nil {
+			return fmt.Errorf("element at index %d: %w", index, err)
+		}
+		if lexicalOrderValidator != nil {
+			return lexicalOrderValidator(index, seriBytes)
+		}
+		return nil
+	}
+}
+
 // Serializables is a slice of Serializable.
 type Serializables []Serializable
 
+// SerializeConcat serializes all elements back to back, without a count prefix, into a single slice.
+// The slice is allocated once, sized by the elements implementing SizeHinter, and elements which also implement
+// BufferSerializable are serialized in place. All other elements are serialized via Serialize and appended.
+func (seris Serializables) SerializeConcat(deSeriMode DeSerializationMode) ([]byte, error) {
+	var size int
+	for _, seri := range seris {
+		if sizeHinter, ok := seri.(SizeHinter); ok {
+			size += sizeHinter.SerializedSize()
+		}
+	}
+
+	buf := make([]byte, 0, size)
+	for i, seri := range seris {
+		sizeHinter, hinted := seri.(SizeHinter)
+		bufSeri, isBufSeri := seri.(BufferSerializable)
+		if hinted && isBufSeri && sizeHinter.SerializedSize() > 0 {
+			offset := len(buf)
+			buf = append(buf, make([]byte, sizeHinter.SerializedSize())...)
+			bytesWritten, err := bufSeri.SerializeInto(buf[offset:], deSeriMode)
+			if err != nil {
+				return nil, fmt.Errorf("unable to serialize element at index %d: %w", i, err)
+			}
+			buf = buf[:offset+bytesWritten]
+			continue
+		}
+		seriBytes, err := seri.Serialize(deSeriMode)
+		if err != nil {
+			return nil, fmt.Errorf("unable to serialize element at index %d: %w", i, err)
+		}
+		buf = append(buf, seriBytes...)
+	}
+	return buf, nil
+}
+
+// SliceOfArraysOf32Bytes is a slice of arrays of which each is 32 bytes.
+type SliceOfArraysOf32Bytes = [][32]byte
+
+// SliceOfArraysOf64Bytes is a slice of arrays of which each is 64 bytes.
+type SliceOfArraysOf64Bytes = [][64]byte
+
 // SerializableSelectorFunc is a function that given a type byte, returns an empty instance of the given underlying type.
 // If the type doesn't resolve, an error is returned.
+// The selectors of this package hold no state and are therefore safe for concurrent use. There is no
+// registry to add types at runtime: new types are added by extending the selector's switch.
 type SerializableSelectorFunc func(ty uint32) (Serializable, error)
 
+// RegisteredTypes returns the sorted types which the given SerializableSelectorFunc resolves.
+// As the selectors are plain switches, the types are found by probing the selector with every value a
+// small type denotation can hold. All types of this package lie within that range.
+func RegisteredTypes(serSel SerializableSelectorFunc) []uint64 {
+	var types []uint64
+	for ty := uint32(0); ty <= math.MaxUint8; ty++ {
+		if _, err := serSel(ty); err == nil {
+			types = append(types, uint64(ty))
+		}
+	}
+	return types
+}
+
 // DeSerializationMode defines the mode of de/serialization.
 type DeSerializationMode byte
 
@@ -34,11 +179,14 @@ const (
 	DeSeriModeNoValidation DeSerializationMode = 0
 	// Instructs de/serialization to perform validation.
 	DeSeriModePerformValidation DeSerializationMode = 1 << 0
+	// Instructs de/serialization to check the lexical order of array elements whose ArrayRules demand it.
+	// It is independent of DeSeriModePerformValidation, so that data can be fully validated with relaxed ordering.
+	DeSeriModePerformLexicalOrdering DeSerializationMode = 1 << 1
 )
 
-// HasMode checks whether the de/serialization mode includes the given mode.
+// HasMode checks whether the de/serialization mode includes all of the given modes.
 func (sm DeSerializationMode) HasMode(mode DeSerializationMode) bool {
-	return sm&mode == 1
+	return sm&mode == mode
 }
 
 // ArrayRules defines rules around a to be deserialized array.
@@ -56,25 +204,62 @@ type ArrayRules struct {
 	ElementBytesLexicalOrder bool
 	// The error returned if the element bytes lexical order is violated.
 	ElementBytesLexicalOrderErr error
+	// The minimum size of a serialized element. If set, counts of elements which can't fit into
+	// the remaining data are rejected before any element is deserialized.
+	MinElementSize int
+	// The optional name of the array, e.g. "outputs", which is included in bound errors.
+	Context string
 }
 
 // CheckBounds checks whether the given count violates the array bounds.
 func (ar *ArrayRules) CheckBounds(count uint16) error {
 	if ar.Min != 0 && count < ar.Min {
-		return fmt.Errorf("%w: min is %d but count is %d", ar.MinErr, ar.Min, count)
+		return ar.boundErr(ar.MinErr, "min", ar.Min, count)
 	}
 	if ar.Max != 0 && count > ar.Max {
-		return fmt.Errorf("%w: max is %d but count is %d", ar.MaxErr, ar.Max, count)
+		return ar.boundErr(ar.MaxErr, "max", ar.Max, count)
+	}
+	return nil
+}
+
+// boundErr wraps err with the violated bound, prefixed by the array's Context if set.
+func (ar *ArrayRules) boundErr(err error, boundName string, bound uint16, count uint16) error {
+	if ar.Context != "" {
+		boundName = ar.Context + " " + boundName
+	}
+	return fmt.Errorf("%w: %s is %d but count is %d", err, boundName, bound, count)
+}
+
+// CheckElementsFit checks whether count elements of at least MinElementSize bytes fit into the remaining bytes.
+func (ar *ArrayRules) CheckElementsFit(count uint16, remaining int) error {
+	if ar.MinElementSize != 0 && int(count)*ar.MinElementSize > remaining {
+		return fmt.Errorf("%w: %d elements of at least %d bytes don't fit into the remaining %d bytes", ErrDeserializationNotEnoughData, count, ar.MinElementSize, remaining)
 	}
 	return nil
 }
 
+// lexicalOrderValidatorFor returns a LexicalOrderFunc if the rules demand the lexical order of the elements
+// and the given mode includes DeSeriModePerformLexicalOrdering, nil otherwise.
+func (ar *ArrayRules) lexicalOrderValidatorFor(deSeriMode DeSerializationMode) LexicalOrderFunc {
+	if ar == nil || !ar.ElementBytesLexicalOrder || !deSeriMode.HasMode(DeSeriModePerformLexicalOrdering) {
+		return nil
+	}
+	return ar.LexicalOrderValidator()
+}
+
 // LexicalOrderFunc is a function which runs during lexical order validation.
 type LexicalOrderFunc func(int, []byte) error
 
 // LexicalOrderValidator returns a LexicalOrderFunc which returns an error if the given byte slices
 // are not ordered lexicographically.
 func (ar *ArrayRules) LexicalOrderValidator() LexicalOrderFunc {
+	return LexicalOrderValidator(ar.ElementBytesLexicalOrderErr)
+}
+
+// LexicalOrderValidator returns a LexicalOrderFunc which returns an error wrapping errOnViolation
+// if the byte slices it is fed one by one are not ordered lexicographically. Equal slices don't violate the order.
+// The slices can be arbitrary bytes, e.g. serialized objects or raw hashes.
+func LexicalOrderValidator(errOnViolation error) LexicalOrderFunc {
 	var prev []byte
 	var prevIndex int
 	return func(index int, next []byte) error {
@@ -83,7 +268,7 @@ func (ar *ArrayRules) LexicalOrderValidator() LexicalOrderFunc {
 			prev = next
 			prevIndex = index
 		case bytes.Compare(prev, next) > 0:
-			return fmt.Errorf("%w: element %d should have been before element %d", ar.ElementBytesLexicalOrderErr, index, prevIndex)
+			return fmt.Errorf("%w: element %d should have been before element %d", errOnViolation, index, prevIndex)
 		default:
 			prev = next
 			prevIndex = index
@@ -107,44 +292,88 @@ func (l LexicalOrderedByteSlices) Swap(i, j int) {
 	l[i], l[j] = l[j], l[i]
 }
 
+// lexicalOrderedSerializables are Serializables ordered by their serialized bytes.
+type lexicalOrderedSerializables struct {
+	seris Serializables
+	data  LexicalOrderedByteSlices
+}
+
+func (l *lexicalOrderedSerializables) Len() int {
+	return len(l.seris)
+}
+
+func (l *lexicalOrderedSerializables) Less(i, j int) bool {
+	return l.data.Less(i, j)
+}
+
+func (l *lexicalOrderedSerializables) Swap(i, j int) {
+	l.seris[i], l.seris[j] = l.seris[j], l.seris[i]
+	l.data.Swap(i, j)
+}
+
+// Sort sorts the Serializables in place by the lexical order of their serialized bytes, serializing them with the given mode.
+// If an element fails to serialize, an error is returned and the order of the elements is left untouched.
+func (seris Serializables) Sort(deSeriMode DeSerializationMode) error {
+	sorter := &lexicalOrderedSerializables{seris: seris, data: make(LexicalOrderedByteSlices, len(seris))}
+	for i, seri := range seris {
+		seriBytes, err := seri.Serialize(deSeriMode)
+		if err != nil {
+			return fmt.Errorf("unable to serialize element %d: %w", i, err)
+		}
+		sorter.data[i] = seriBytes
+	}
+	sort.Sort(sorter)
+	return nil
+}
+
 // DeserializeArrayOfObjects deserializes the given data into Serializables.
-// The data is expected to start with the count denoting varint, followed by the actual structs.
+// The data is expected to start with the uint16 count of the elements, followed by the actual structs.
 // An optional ArrayRules can be passed in to return an error in case it is violated.
 func DeserializeArrayOfObjects(data []byte, deSeriMode DeSerializationMode, typeDen TypeDenotationType, serSel SerializableSelectorFunc, arrayRules *ArrayRules) (Serializables, int, error) {
+	return DeserializeArrayOfObjectsInto(nil, data, deSeriMode, typeDen, serSel, arrayRules)
+}
+
+// DeserializeArrayOfObjectsInto works like DeserializeArrayOfObjects but appends the deserialized Serializables to dst
+// and returns the extended slice. Passing dst[:0] reuses the backing array of dst if its capacity suffices.
+// On error, nil and a *DeserializationError are returned.
+func DeserializeArrayOfObjectsInto(dst Serializables, data []byte, deSeriMode DeSerializationMode, typeDen TypeDenotationType, serSel SerializableSelectorFunc, arrayRules *ArrayRules) (Serializables, int, error) {
 	var bytesReadTotal int
 
 	if len(data) < StructArrayLengthByteSize {
-		return nil, 0, fmt.Errorf("%w: not enough data to deserialize struct array", ErrDeserializationNotEnoughData)
+		return nil, 0, deserializationErrorAt(0, fmt.Errorf("%w: not enough data to deserialize struct array", ErrDeserializationNotEnoughData), "")
 	}
 
 	seriCount := binary.LittleEndian.Uint16(data)
 	bytesReadTotal += StructArrayLengthByteSize
 
-	if arrayRules != nil && deSeriMode.HasMode(DeSeriModePerformValidation) {
-		if err := arrayRules.CheckBounds(seriCount); err != nil {
-			return nil, 0, err
+	if arrayRules != nil {
+		if deSeriMode.HasMode(DeSeriModePerformValidation) {
+			if err := arrayRules.CheckBounds(seriCount); err != nil {
+				return nil, 0, deserializationErrorAt(0, err, "")
+			}
+		}
+		if err := arrayRules.CheckElementsFit(seriCount, len(data)-StructArrayLengthByteSize); err != nil {
+			return nil, 0, deserializationErrorAt(0, err, "")
 		}
 	}
 
 	// advance to objects
-	var seris Serializables
+	seris := dst
 	data = data[StructArrayLengthByteSize:]
 
-	var lexicalOrderValidator LexicalOrderFunc
-	if arrayRules != nil && arrayRules.ElementBytesLexicalOrder {
-		lexicalOrderValidator = arrayRules.LexicalOrderValidator()
-	}
+	lexicalOrderValidator := arrayRules.lexicalOrderValidatorFor(deSeriMode)
 
 	var offset int
 	for i := 0; i < int(seriCount); i++ {
+		// the selector also sees unknown types in DeSeriModeNoValidation, so name the element it rejected
 		seri, seriBytesConsumed, err := DeserializeObject(data[offset:], deSeriMode, typeDen, serSel)
 		if err != nil {
-			return nil, 0, err
+			return nil, 0, deserializationErrorAt(StructArrayLengthByteSize+offset, err, fmt.Sprintf("element %d", i))
 		}
 		// check lexical order against previous element
 		if lexicalOrderValidator != nil {
 			if err := lexicalOrderValidator(i, data[offset:offset+seriBytesConsumed]); err != nil {
-				return nil, 0, err
+				return nil, 0, deserializationErrorAt(StructArrayLengthByteSize+offset, err, "")
 			}
 		}
 		seris = append(seris, seri)
@@ -155,33 +384,303 @@ func DeserializeArrayOfObjects(data []byte, deSeriMode DeSerializationMode, type
 	return seris, bytesReadTotal, nil
 }
 
-// DeserializeObject deserializes the given data into a Serializable.
-// The data is expected to start with the type denotation.
-func DeserializeObject(data []byte, deSeriMode DeSerializationMode, typeDen TypeDenotationType, serSel SerializableSelectorFunc) (Serializable, int, error) {
-	var ty uint32
+// ReadType reads the type denotation of the given TypeDenotationType from the start of data
+// and returns it along with the amount of bytes it occupies. Nothing is read for TypeDenotationNone.
+func ReadType(data []byte, typeDen TypeDenotationType) (uint32, int, error) {
 	switch typeDen {
 	case TypeDenotationUint32:
-		if len(data) < UInt32ByteSize+1 {
-			return nil, 0, ErrDeserializationNotEnoughData
+		if len(data) < UInt32ByteSize {
+			return 0, 0, fmt.Errorf("%w: can't read uint32 type denotation", ErrDeserializationNotEnoughData)
 		}
-		ty = binary.LittleEndian.Uint32(data)
+		return binary.LittleEndian.Uint32(data), UInt32ByteSize, nil
 	case TypeDenotationByte:
-		if len(data) < OneByte+1 {
-			return nil, 0, ErrDeserializationNotEnoughData
+		if len(data) < OneByte {
+			return 0, 0, fmt.Errorf("%w: can't read byte type denotation", ErrDeserializationNotEnoughData)
 		}
-		ty = uint32(data[0])
+		return uint32(data[0]), OneByte, nil
+	}
+	return 0, 0, nil
+}
+
+// readObjectType reads the type denotation of an object, which must be followed by the object's data.
+func readObjectType(data []byte, typeDen TypeDenotationType) (uint32, error) {
+	ty, tyBytesRead, err := ReadType(data, typeDen)
+	if err != nil {
+		return 0, err
+	}
+	if tyBytesRead != 0 && len(data) == tyBytesRead {
+		return 0, fmt.Errorf("%w: no data follows the type denotation", ErrDeserializationNotEnoughData)
 	}
+	return ty, nil
+}
+
+// selectObject returns the Serializable serSel selects for the given type.
+// It guards against selectors returning neither an object nor an error.
+func selectObject(serSel SerializableSelectorFunc, ty uint32) (Serializable, error) {
 	seri, err := serSel(ty)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	if seri == nil {
+		return nil, fmt.Errorf("%w: for type %d", ErrSelectorReturnedNil, ty)
+	}
+	return seri, nil
+}
+
+// DeserializeObject deserializes the given data into a Serializable.
+// The data is expected to start with the type denotation, unless TypeDenotationNone is given.
+// Errors are returned as a *DeserializationError.
+func DeserializeObject(data []byte, deSeriMode DeSerializationMode, typeDen TypeDenotationType, serSel SerializableSelectorFunc) (Serializable, int, error) {
+	ty, err := readObjectType(data, typeDen)
+	if err != nil {
+		return nil, 0, deserializationErrorAt(0, err, "")
+	}
+	seri, err := selectObject(serSel, ty)
+	if err != nil {
+		return nil, 0, deserializationErrorAt(0, err, "")
 	}
 	seriBytesConsumed, err := seri.Deserialize(data, deSeriMode)
 	if err != nil {
-		return nil, 0, fmt.Errorf("unable to deserialize %T: %w", seri, err)
+		return nil, 0, deserializationErrorAt(0, err, fmt.Sprintf("unable to deserialize %T", seri))
 	}
 	return seri, seriBytesConsumed, nil
 }
 
+// DeserializeObjectStrict deserializes the given data via DeserializeObject and returns
+// an error wrapping ErrTrailingBytes if the object doesn't consume all of data.
+// Use it wherever data is supposed to hold exactly one object, for example a request body.
+func DeserializeObjectStrict(data []byte, deSeriMode DeSerializationMode, typeDen TypeDenotationType, serSel SerializableSelectorFunc) (Serializable, error) {
+	seri, bytesConsumed, err := DeserializeObject(data, deSeriMode, typeDen, serSel)
+	if err != nil {
+		return nil, err
+	}
+	if bytesConsumed != len(data) {
+		return nil, fmt.Errorf("%w: %d bytes were consumed but data is %d bytes long", ErrTrailingBytes, bytesConsumed, len(data))
+	}
+	return seri, nil
+}
+
+// CloneSerializable deep-copies the given Serializable by serializing it and deserializing the bytes into a new
+// object of the same type, both without validation. seri must be a pointer, as all Serializables of this package are.
+// A nil Serializable is cloned to nil, and nil payloads stay nil as they serialize to a zero payload length.
+func CloneSerializable(seri Serializable) (Serializable, error) {
+	if seri == nil {
+		return nil, nil
+	}
+	seriType := reflect.TypeOf(seri)
+	if seriType.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("can only clone pointers to Serializables but got %T", seri)
+	}
+	data, err := seri.Serialize(DeSeriModeNoValidation)
+	if err != nil {
+		return nil, fmt.Errorf("unable to serialize %T for cloning: %w", seri, err)
+	}
+	clone := reflect.New(seriType.Elem()).Interface().(Serializable)
+	bytesRead, err := clone.Deserialize(data, DeSeriModeNoValidation)
+	if err != nil {
+		return nil, fmt.Errorf("unable to deserialize %T for cloning: %w", seri, err)
+	}
+	if bytesRead != len(data) {
+		return nil, fmt.Errorf("%w: %T only consumed %d of its %d serialized bytes", ErrDeserializationNotAllConsumed, seri, bytesRead, len(data))
+	}
+	return clone, nil
+}
+
+// VerifyRoundTrip checks that seri serializes deterministically: serializing it twice must yield identical bytes,
+// and deserializing those bytes into a new object of the same type must consume all of them and serialize back into them.
+// Both directions are done without validation. An error wrapping ErrNonDeterministicSerialization is returned on any discrepancy.
+func VerifyRoundTrip(seri Serializable) error {
+	data, err := seri.Serialize(DeSeriModeNoValidation)
+	if err != nil {
+		return fmt.Errorf("unable to serialize %T: %w", seri, err)
+	}
+	again, err := seri.Serialize(DeSeriModeNoValidation)
+	if err != nil {
+		return fmt.Errorf("unable to serialize %T a second time: %w", seri, err)
+	}
+	if !bytes.Equal(data, again) {
+		return fmt.Errorf("%w: %T serializes to different bytes on subsequent calls", ErrNonDeterministicSerialization, seri)
+	}
+
+	clone, err := CloneSerializable(seri)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNonDeterministicSerialization, err)
+	}
+	reserialized, err := clone.Serialize(DeSeriModeNoValidation)
+	if err != nil {
+		return fmt.Errorf("%w: unable to reserialize deserialized %T: %v", ErrNonDeterministicSerialization, seri, err)
+	}
+	if !bytes.Equal(data, reserialized) {
+		return fmt.Errorf("%w: deserialized %T serializes to different bytes", ErrNonDeterministicSerialization, seri)
+	}
+	return nil
+}
+
+// serializedSizeOf returns the length of the serialized form of seri, using its SizeHinter implementation if it has one
+// which knows the length and serializing it without validation otherwise.
+func serializedSizeOf(seri Serializable) (int, error) {
+	if hinter, ok := seri.(SizeHinter); ok {
+		if size := hinter.SerializedSize(); size != 0 {
+			return size, nil
+		}
+	}
+	data, err := seri.Serialize(DeSeriModeNoValidation)
+	if err != nil {
+		return 0, fmt.Errorf("unable to serialize %T to determine its size: %w", seri, err)
+	}
+	return len(data), nil
+}
+
+// WriteToMode is the mode with which the io.WriterTo implementations of this package serialize.
+// As their output is meant to leave the process, they fully validate the written objects.
+const WriteToMode = DeSeriModePerformValidation | DeSeriModePerformLexicalOrdering
+
+// writeSerializableTo serializes seri with WriteToMode and writes it to w.
+func writeSerializableTo(w io.Writer, seri Serializable) (int64, error) {
+	data, err := seri.Serialize(WriteToMode)
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// writeFixedSizeTo serializes seri with WriteToMode into buf, which must hold exactly its constant size, and writes it to w.
+func writeFixedSizeTo(w io.Writer, seri BufferSerializable, buf []byte) (int64, error) {
+	if _, err := seri.SerializeInto(buf, WriteToMode); err != nil {
+		return 0, err
+	}
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// SerializeToHex serializes the given Serializable and encodes its serialized form as a hex string.
+func SerializeToHex(seri Serializable, deSeriMode DeSerializationMode) (string, error) {
+	data, err := seri.Serialize(deSeriMode)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(data), nil
+}
+
+// DeserializeFromHex decodes the given hex string and deserializes it via DeserializeObjectStrict.
+// The decoded data must be consumed entirely by the deserialized object.
+func DeserializeFromHex(hexStr string, deSeriMode DeSerializationMode, typeDen TypeDenotationType, serSel SerializableSelectorFunc) (Serializable, error) {
+	data, err := hex.DecodeString(hexStr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidHex, err)
+	}
+	return DeserializeObjectStrict(data, deSeriMode, typeDen, serSel)
+}
+
+// DeserializeFromReader reads an object prefixed by its uint32 length denotation from r and deserializes it.
+// The object must start with a uint32 type denotation resolvable by serSel and must consume exactly its denoted length.
+// Reads are done incrementally, so fragmented readers are supported and no buffer of the denoted length is allocated
+// before the data actually arrived. It returns the amount of bytes read from r. io.EOF is returned if r holds no more data
+// and an error wrapping ErrDeserializationNotEnoughData if r ends within the object.
+// As no object exceeds a message, lengths above MaxMessageLength are rejected with an error wrapping ErrObjectTooLarge
+// before anything else is read.
+func DeserializeFromReader(r io.Reader, deSeriMode DeSerializationMode, serSel SerializableSelectorFunc) (Serializable, int, error) {
+	var lengthBytes [UInt32ByteSize]byte
+	if n, err := io.ReadFull(r, lengthBytes[:]); err != nil {
+		if n == 0 && errors.Is(err, io.EOF) {
+			return nil, 0, io.EOF
+		}
+		return nil, 0, fmt.Errorf("%w: unable to read object length denotation: %v", ErrDeserializationNotEnoughData, err)
+	}
+	length := binary.LittleEndian.Uint32(lengthBytes[:])
+	if length > MaxMessageLength {
+		return nil, 0, fmt.Errorf("%w: object length denotes %d bytes but max is %d", ErrObjectTooLarge, length, MaxMessageLength)
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if n, err := io.CopyN(buf, r, int64(length)); err != nil {
+		return nil, 0, fmt.Errorf("%w: object length denotes %d bytes but only %d could be read: %v", ErrDeserializationNotEnoughData, length, n, err)
+	}
+
+	seri, seriBytesConsumed, err := DeserializeObject(buf.Bytes(), deSeriMode, TypeDenotationUint32, serSel)
+	if err != nil {
+		return nil, 0, err
+	}
+	if seriBytesConsumed != int(length) {
+		return nil, 0, fmt.Errorf("%w: denoted object length (%d) doesn't equal the size of the deserialized object (%d)", ErrDeserializationNotAllConsumed, length, seriBytesConsumed)
+	}
+	return seri, UInt32ByteSize + int(length), nil
+}
+
+// ValidateBytes checks the consistency of the serialized object at the beginning of data, which must start with
+// a uint32 type denotation, and returns the amount of bytes the object occupies. Counts, lengths and types are checked
+// without populating any objects: the Serializable returned by serSel is only used to dispatch to its BytesValidator
+// implementation. Types which don't implement BytesValidator are checked by deserializing them instead.
+// If the DeSeriModePerformValidation mode is given, array bounds and field values are validated too, however,
+// validations which need multiple deserialized objects, like the uniqueness of inputs, are not performed.
+func ValidateBytes(data []byte, deSeriMode DeSerializationMode, serSel SerializableSelectorFunc) (int, error) {
+	return validateObjectBytes(data, deSeriMode, TypeDenotationUint32, serSel)
+}
+
+// validateObjectBytes is the BytesValidator counterpart of DeserializeObject.
+func validateObjectBytes(data []byte, deSeriMode DeSerializationMode, typeDen TypeDenotationType, serSel SerializableSelectorFunc) (int, error) {
+	ty, err := readObjectType(data, typeDen)
+	if err != nil {
+		return 0, err
+	}
+	seri, err := selectObject(serSel, ty)
+	if err != nil {
+		return 0, err
+	}
+	bytesValidator, ok := seri.(BytesValidator)
+	if !ok {
+		seriBytesConsumed, err := seri.Deserialize(data, deSeriMode)
+		if err != nil {
+			return 0, fmt.Errorf("unable to deserialize %T: %w", seri, err)
+		}
+		return seriBytesConsumed, nil
+	}
+	seriBytesConsumed, err := bytesValidator.ValidateBytes(data, deSeriMode)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %T bytes: %w", seri, err)
+	}
+	return seriBytesConsumed, nil
+}
+
+// validateArrayOfObjectsBytes is the BytesValidator counterpart of DeserializeArrayOfObjects.
+func validateArrayOfObjectsBytes(data []byte, deSeriMode DeSerializationMode, typeDen TypeDenotationType, serSel SerializableSelectorFunc, arrayRules *ArrayRules) (int, error) {
+	if len(data) < StructArrayLengthByteSize {
+		return 0, fmt.Errorf("%w: not enough data to deserialize struct array", ErrDeserializationNotEnoughData)
+	}
+
+	seriCount := binary.LittleEndian.Uint16(data)
+	if arrayRules != nil {
+		if deSeriMode.HasMode(DeSeriModePerformValidation) {
+			if err := arrayRules.CheckBounds(seriCount); err != nil {
+				return 0, err
+			}
+		}
+		if err := arrayRules.CheckElementsFit(seriCount, len(data)-StructArrayLengthByteSize); err != nil {
+			return 0, err
+		}
+	}
+
+	lexicalOrderValidator := arrayRules.lexicalOrderValidatorFor(deSeriMode)
+
+	offset := StructArrayLengthByteSize
+	for i := 0; i < int(seriCount); i++ {
+		seriBytesConsumed, err := validateObjectBytes(data[offset:], deSeriMode, typeDen, serSel)
+		if err != nil {
+			return 0, err
+		}
+		if lexicalOrderValidator != nil {
+			if err := lexicalOrderValidator(i, data[offset:offset+seriBytesConsumed]); err != nil {
+				return 0, err
+			}
+		}
+		offset += seriBytesConsumed
+	}
+
+	return offset, nil
+}
+
 // ReadStringFromBytes reads a string from data by first reading the string length by reading a uint16
 // and then consuming that length from data.
 func ReadStringFromBytes(data []byte) (string, int, error) {
@@ -4,8 +4,13 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"io"
+	"math"
+	"math/rand"
 	"sort"
+	"sync"
 	"testing"
+	"testing/iotest"
 
 	"github.com/luca-moser/iota"
 	"github.com/stretchr/testify/assert"
@@ -117,6 +122,60 @@ func TestDeserializeObject(t *testing.T) {
 	assert.Equal(t, seriA[iota.SmallTypeDenotationByteSize:], objA.(*A).Key[:])
 }
 
+func TestDeserializeObject_SelectorReturnsNil(t *testing.T) {
+	nilSelector := func(uint32) (iota.Serializable, error) { return nil, nil }
+	seriA := randSerializedA()
+
+	assert.NotPanics(t, func() {
+		_, _, err := iota.DeserializeObject(seriA, iota.DeSeriModePerformValidation, iota.TypeDenotationByte, nilSelector)
+		assert.True(t, errors.Is(err, iota.ErrSelectorReturnedNil), "expected nil selector error, got %v", err)
+
+		_, err = iota.NewDeserializer(seriA).SkipObject(iota.DeSeriModePerformValidation, iota.TypeDenotationByte, nilSelector, "object").Done()
+		assert.True(t, errors.Is(err, iota.ErrSelectorReturnedNil), "expected nil selector error, got %v", err)
+	})
+}
+
+func TestReadType(t *testing.T) {
+	type test struct {
+		name      string
+		data      []byte
+		typeDen   iota.TypeDenotationType
+		ty        uint32
+		bytesRead int
+		err       error
+	}
+	tests := []test{
+		{"uint32", []byte{0x04, 0x03, 0x02, 0x01, 0xFF}, iota.TypeDenotationUint32, 0x01020304, iota.UInt32ByteSize, nil},
+		{"byte", []byte{0x2A, 0xFF}, iota.TypeDenotationByte, 0x2A, iota.OneByte, nil},
+		{"none", []byte{0x2A}, iota.TypeDenotationNone, 0, 0, nil},
+		{"uint32 not enough data", []byte{0x04, 0x03, 0x02}, iota.TypeDenotationUint32, 0, 0, iota.ErrDeserializationNotEnoughData},
+		{"byte not enough data", []byte{}, iota.TypeDenotationByte, 0, 0, iota.ErrDeserializationNotEnoughData},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ty, bytesRead, err := iota.ReadType(tt.data, tt.typeDen)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.ty, ty)
+			assert.Equal(t, tt.bytesRead, bytesRead)
+		})
+	}
+
+	// the read type is the one DeserializeObject selects by
+	a := randA()
+	aData, err := a.Serialize(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	ty, _, err := iota.ReadType(aData, iota.TypeDenotationByte)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(TypeA), ty)
+	seri, _, err := iota.DeserializeObject(aData, iota.DeSeriModeNoValidation, iota.TypeDenotationByte, DummyTypeSelector)
+	assert.NoError(t, err)
+	assert.IsType(t, &A{}, seri)
+}
+
 func TestDeserializeArrayOfObjects(t *testing.T) {
 	var buf bytes.Buffer
 	originObjs := iota.Serializables{
@@ -139,6 +198,61 @@ func TestDeserializeArrayOfObjects(t *testing.T) {
 	assert.EqualValues(t, originObjs, seris)
 }
 
+func TestDeserializeArrayOfObjects_CountExceedsData(t *testing.T) {
+	// a hostile count followed by a single element
+	data := append([]byte{0xFF, 0xFF}, make([]byte, typeALength)...)
+	arrayRules := &iota.ArrayRules{MinElementSize: typeALength}
+
+	var selected int
+	countingSelector := func(ty uint32) (iota.Serializable, error) {
+		selected++
+		return DummyTypeSelector(ty)
+	}
+
+	for _, mode := range []iota.DeSerializationMode{iota.DeSeriModeNoValidation, iota.DeSeriModePerformValidation} {
+		_, _, err := iota.DeserializeArrayOfObjects(data, mode, iota.TypeDenotationByte, countingSelector, arrayRules)
+		assert.True(t, errors.Is(err, iota.ErrDeserializationNotEnoughData), "expected not enough data error, got %v", err)
+	}
+	assert.Zero(t, selected, "no element should have been deserialized")
+
+	// the count still has to fit if the elements are skipped
+	unTxData := []byte{0, 0, 0, 0, iota.UnsignedTransactionFormatVersion, 0xFF, 0xFF, iota.InputUTXO}
+	payloadData := append([]byte{0, 0, 0, 0}, unTxData...)
+	_, err := (&iota.SignedTransactionPayload{}).ValidateBytes(payloadData, iota.DeSeriModeNoValidation)
+	assert.True(t, errors.Is(err, iota.ErrDeserializationNotEnoughData), "expected not enough data error, got %v", err)
+	_, err = (&iota.UnsignedTransaction{}).Deserialize(unTxData, iota.DeSeriModeNoValidation)
+	assert.True(t, errors.Is(err, iota.ErrDeserializationNotEnoughData), "expected not enough data error, got %v", err)
+}
+
+func TestDeserializeArrayOfObjectsInto(t *testing.T) {
+	originObjs := iota.Serializables{randA(), randB(), randA()}
+	data, err := iota.NewSerializer().WriteSliceOfObjects(originObjs, iota.DeSeriModePerformValidation, nil, "objects").Serialize()
+	assert.NoError(t, err)
+
+	t.Run("appends to dst", func(t *testing.T) {
+		prefix := randB()
+		seris, serisBytesRead, err := iota.DeserializeArrayOfObjectsInto(iota.Serializables{prefix}, data, iota.DeSeriModePerformValidation, iota.TypeDenotationByte, DummyTypeSelector, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, len(data), serisBytesRead)
+		assert.EqualValues(t, append(iota.Serializables{prefix}, originObjs...), seris)
+	})
+
+	t.Run("reuses backing array", func(t *testing.T) {
+		dst := make(iota.Serializables, 0, 10)
+		seris, _, err := iota.DeserializeArrayOfObjectsInto(dst, data, iota.DeSeriModePerformValidation, iota.TypeDenotationByte, DummyTypeSelector, nil)
+		assert.NoError(t, err)
+		assert.EqualValues(t, originObjs, seris)
+		assert.Equal(t, 10, cap(seris))
+		assert.Same(t, &dst[:1][0], &seris[0])
+	})
+
+	t.Run("error", func(t *testing.T) {
+		seris, _, err := iota.DeserializeArrayOfObjectsInto(make(iota.Serializables, 0, 10), data[:iota.StructArrayLengthByteSize], iota.DeSeriModePerformValidation, iota.TypeDenotationByte, DummyTypeSelector, nil)
+		assert.Error(t, err)
+		assert.Nil(t, seris)
+	})
+}
+
 func TestLexicalOrderedByteSlices(t *testing.T) {
 	type test struct {
 		name   string
@@ -182,6 +296,60 @@ func TestLexicalOrderedByteSlices(t *testing.T) {
 	}
 }
 
+func TestLexicalOrderValidator(t *testing.T) {
+	errViolation := errors.New("violation")
+	type test struct {
+		name   string
+		hashes iota.SliceOfArraysOf32Bytes
+		err    error
+	}
+	tests := []test{
+		{"ordered", iota.SliceOfArraysOf32Bytes{{1}, {1, 2}, {2}}, nil},
+		{"equal", iota.SliceOfArraysOf32Bytes{{1}, {1}}, nil},
+		{"unordered", iota.SliceOfArraysOf32Bytes{{1}, {3}, {2}}, errViolation},
+		{"single", iota.SliceOfArraysOf32Bytes{{1}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := iota.LexicalOrderValidator(errViolation)
+			var err error
+			for i := range tt.hashes {
+				if err = validator(i, tt.hashes[i][:]); err != nil {
+					break
+				}
+			}
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "unexpected error %v", err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSerializables_Sort(t *testing.T) {
+	sigs := make(iota.Serializables, 10)
+	for i := range sigs {
+		sigs[i], _ = randEd25519Signature()
+	}
+	rand.Shuffle(len(sigs), func(i, j int) { sigs[i], sigs[j] = sigs[j], sigs[i] })
+
+	assert.NoError(t, sigs.Sort(iota.DeSeriModePerformValidation))
+	sigsBytes := make(iota.LexicalOrderedByteSlices, len(sigs))
+	for i, sig := range sigs {
+		sigBytes, err := sig.Serialize(iota.DeSeriModePerformValidation)
+		assert.NoError(t, err)
+		sigsBytes[i] = sigBytes
+	}
+	assert.True(t, sort.IsSorted(sigsBytes))
+
+	// an element which can't be serialized leaves the order untouched
+	unsortable := iota.Serializables{sigs[1], sigs[0], &iota.WOTSSignature{}}
+	err := unsortable.Sort(iota.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, iota.ErrWOTSSignatureNotSupported), "unexpected error %v", err)
+	assert.Equal(t, iota.Serializables{sigs[1], sigs[0], &iota.WOTSSignature{}}, unsortable)
+}
+
 func TestSerializationMode_HasMode(t *testing.T) {
 	type args struct {
 		mode iota.DeSerializationMode
@@ -204,6 +372,18 @@ func TestSerializationMode_HasMode(t *testing.T) {
 			args{mode: iota.DeSeriModePerformValidation},
 			true,
 		},
+		{
+			"has validation but not lexical ordering",
+			iota.DeSeriModePerformValidation,
+			args{mode: iota.DeSeriModePerformValidation | iota.DeSeriModePerformLexicalOrdering},
+			false,
+		},
+		{
+			"has validation and lexical ordering",
+			iota.DeSeriModePerformValidation | iota.DeSeriModePerformLexicalOrdering,
+			args{mode: iota.DeSeriModePerformLexicalOrdering},
+			true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -262,3 +442,508 @@ func TestReadStringFromBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectorsConcurrentUse(t *testing.T) {
+	selectors := []iota.SerializableSelectorFunc{
+		iota.AddressSelector, iota.SignatureSelector, iota.InputSelector, iota.OutputSelector,
+		iota.UnlockBlockSelector, iota.TransactionSelector, iota.PayloadSelector,
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		for _, sel := range selectors {
+			wg.Add(1)
+			go func(sel iota.SerializableSelectorFunc) {
+				defer wg.Done()
+				seri, err := sel(0)
+				assert.NoError(t, err)
+				assert.NotNil(t, seri)
+			}(sel)
+		}
+	}
+	wg.Wait()
+}
+
+func TestRegisteredTypes(t *testing.T) {
+	tests := []struct {
+		name   string
+		serSel iota.SerializableSelectorFunc
+		types  []uint64
+	}{
+		{"addresses", iota.AddressSelector, []uint64{uint64(iota.AddressWOTS), uint64(iota.AddressEd25519), uint64(iota.AddressAlias), uint64(iota.AddressNFT)}},
+		{"inputs", iota.InputSelector, []uint64{uint64(iota.InputUTXO), uint64(iota.InputTreasury)}},
+		{"outputs", iota.OutputSelector, []uint64{
+			uint64(iota.OutputSigLockedSingleOutput), uint64(iota.OutputSigLockedDustAllowanceOutput), uint64(iota.OutputTreasuryOutput),
+		}},
+		{"unlock blocks", iota.UnlockBlockSelector, []uint64{uint64(iota.UnlockBlockSignature), uint64(iota.UnlockBlockReference)}},
+		{"payloads", iota.PayloadSelector, []uint64{
+			uint64(iota.SignedTransactionPayloadID), uint64(iota.MilestonePayloadID), uint64(iota.IndexationPayloadID),
+			uint64(iota.ReceiptPayloadID), uint64(iota.TreasuryTransactionPayloadID),
+		}},
+		{"dummies", DummyTypeSelector, []uint64{uint64(TypeA), uint64(TypeB)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.types, iota.RegisteredTypes(tt.serSel))
+		})
+	}
+}
+
+func TestDeserializeObjectStrict(t *testing.T) {
+	sig, sigData := randEd25519Signature()
+
+	seri, err := iota.DeserializeObjectStrict(sigData, iota.DeSeriModePerformValidation, iota.TypeDenotationUint32, iota.SignatureSelector)
+	assert.NoError(t, err)
+	assert.EqualValues(t, sig, seri)
+
+	_, err = iota.DeserializeObjectStrict(append(sigData, 0, 1), iota.DeSeriModePerformValidation, iota.TypeDenotationUint32, iota.SignatureSelector)
+	assert.True(t, errors.Is(err, iota.ErrTrailingBytes), "expected trailing bytes error, got %v", err)
+	assert.True(t, errors.Is(err, iota.ErrDeserializationNotAllConsumed))
+}
+
+func TestSizeHinter(t *testing.T) {
+	type test struct {
+		name string
+		seri iota.Serializable
+	}
+	tests := []test{
+		func() test {
+			addr, _ := randWOTSAddr()
+			return test{"WOTS address", addr}
+		}(),
+		func() test {
+			addr, _ := randEd25519Addr()
+			return test{"Ed25519 address", addr}
+		}(),
+		func() test {
+			addr, _ := randAliasAddr()
+			return test{"alias address", addr}
+		}(),
+		func() test {
+			addr, _ := randNFTAddr()
+			return test{"NFT address", addr}
+		}(),
+		func() test {
+			sig, _ := randEd25519Signature()
+			return test{"Ed25519 signature", sig}
+		}(),
+		func() test {
+			input, _ := randUTXOInput()
+			return test{"UTXO input", input}
+		}(),
+		{"treasury input", &iota.TreasuryInput{1}},
+		func() test {
+			output, _ := randSigLockedSingleOutput(iota.AddressWOTS)
+			return test{"WOTS sig locked single output", output}
+		}(),
+		func() test {
+			output, _ := randSigLockedSingleOutput(iota.AddressEd25519)
+			return test{"sig locked single output", output}
+		}(),
+		func() test {
+			output, _ := randSigLockedDustAllowanceOutput(iota.DustAllowanceMinimum)
+			return test{"sig locked dust allowance output", output}
+		}(),
+		{"treasury output", &iota.TreasuryOutput{Amount: 1337}},
+		func() test {
+			block, _ := randEd25519SignatureUnlockBlock()
+			return test{"signature unlock block", block}
+		}(),
+		func() test {
+			block, _ := randReferenceUnlockBlock()
+			return test{"reference unlock block", block}
+		}(),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sizeHinter, ok := tt.seri.(iota.SizeHinter)
+			if !assert.True(t, ok, "%T doesn't implement SizeHinter", tt.seri) {
+				return
+			}
+			data, err := tt.seri.Serialize(iota.DeSeriModePerformValidation)
+			assert.NoError(t, err)
+			assert.Equal(t, len(data), sizeHinter.SerializedSize())
+		})
+	}
+
+	// unknown nested types can't be hinted
+	assert.Zero(t, (&iota.SigLockedSingleOutput{}).SerializedSize())
+	assert.Zero(t, (&iota.SignatureUnlockBlock{}).SerializedSize())
+}
+
+func TestSerializables_SerializeConcat(t *testing.T) {
+	wotsOutput, wotsOutputData := randSigLockedSingleOutput(iota.AddressWOTS)
+	edOutput, edOutputData := randSigLockedSingleOutput(iota.AddressEd25519)
+	dustOutput, dustOutputData := randSigLockedDustAllowanceOutput(iota.DustAllowanceMinimum)
+	treasuryOutput := &iota.TreasuryOutput{Amount: 1337}
+	treasuryOutputData, err := treasuryOutput.Serialize(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+
+	// mixes elements serialized in place with ones which are appended
+	seris := iota.Serializables{wotsOutput, dustOutput, edOutput, treasuryOutput}
+	data, err := seris.SerializeConcat(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.Equal(t, bytes.Join([][]byte{wotsOutputData, dustOutputData, edOutputData, treasuryOutputData}, nil), data)
+
+	_, err = iota.Serializables{&iota.SigLockedSingleOutput{Address: &iota.Ed25519Address{}}}.SerializeConcat(iota.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, iota.ErrDepositAmountMustBeGreaterThanZero), "unexpected error %v", err)
+
+	data, err = iota.Serializables{}.SerializeConcat(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.Empty(t, data)
+}
+
+func TestCloneSerializable(t *testing.T) {
+	unTx, _ := randUnsignedTransaction()
+	cloneSeri, err := iota.CloneSerializable(unTx)
+	if !assert.NoError(t, err) {
+		return
+	}
+	clone := cloneSeri.(*iota.UnsignedTransaction)
+	assert.EqualValues(t, unTx, clone)
+	assert.Nil(t, clone.Payload)
+
+	// mutating the clone leaves the original untouched
+	originalAmount := unTx.Outputs[0].(*iota.SigLockedSingleOutput).Amount
+	clone.Outputs[0].(*iota.SigLockedSingleOutput).Amount++
+	clone.Inputs = clone.Inputs[:0]
+	assert.Equal(t, originalAmount, unTx.Outputs[0].(*iota.SigLockedSingleOutput).Amount)
+	assert.NotEmpty(t, unTx.Inputs)
+
+	msg, _ := randMessage(iota.IndexationPayloadID)
+	msgClone, err := iota.CloneSerializable(msg)
+	assert.NoError(t, err)
+	assert.EqualValues(t, msg, msgClone)
+	assert.NotSame(t, msg.Payload, msgClone.(*iota.Message).Payload)
+
+	nilClone, err := iota.CloneSerializable(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, nilClone)
+}
+
+func TestHexRoundTrip(t *testing.T) {
+	sig, _ := randEd25519Signature()
+	unTx, _ := randUnsignedTransaction()
+	tests := []struct {
+		name   string
+		source iota.Serializable
+		serSel iota.SerializableSelectorFunc
+	}{
+		{"Ed25519 signature", sig, iota.SignatureSelector},
+		{"unsigned transaction", unTx, iota.TransactionSelector},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hexStr, err := iota.SerializeToHex(tt.source, iota.DeSeriModePerformValidation)
+			assert.NoError(t, err)
+			seri, err := iota.DeserializeFromHex(hexStr, iota.DeSeriModePerformValidation, iota.TypeDenotationUint32, tt.serSel)
+			assert.NoError(t, err)
+			assert.EqualValues(t, tt.source, seri)
+		})
+	}
+}
+
+func TestDeserializeFromHex(t *testing.T) {
+	sig, _ := randEd25519Signature()
+	sigHex, err := iota.SerializeToHex(sig, iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		hexStr string
+		err    error
+	}{
+		{"ok", sigHex, nil},
+		{"odd length", sigHex[1:], iota.ErrInvalidHex},
+		{"not hex", "zz" + sigHex[2:], iota.ErrInvalidHex},
+		{"trailing bytes", sigHex + "00", iota.ErrDeserializationNotAllConsumed},
+		{"unknown type", "ff" + sigHex[2:], iota.ErrUnknownSignatureType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := iota.DeserializeFromHex(tt.hexStr, iota.DeSeriModePerformValidation, iota.TypeDenotationUint32, iota.SignatureSelector)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "expected %v, got %v", tt.err, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// fails every read
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("must not be read")
+}
+
+func TestDeserializeFromReader(t *testing.T) {
+	frame := func(data []byte) []byte {
+		framed, err := iota.NewSerializer().WriteVariableBytes(data, "object").Serialize()
+		must(err)
+		return framed
+	}
+
+	_, sigTxPayData := randSignedTransactionPayload()
+	_, indexationPayloadData := randIndexationPayload()
+	stream := append(frame(sigTxPayData), frame(indexationPayloadData)...)
+
+	t.Run("fragmented reads", func(t *testing.T) {
+		r := iotest.OneByteReader(bytes.NewReader(stream))
+		seri, bytesRead, err := iota.DeserializeFromReader(r, iota.DeSeriModePerformValidation, iota.PayloadSelector)
+		assert.NoError(t, err)
+		assert.Equal(t, iota.UInt32ByteSize+len(sigTxPayData), bytesRead)
+		assert.IsType(t, &iota.SignedTransactionPayload{}, seri)
+
+		seri, bytesRead, err = iota.DeserializeFromReader(r, iota.DeSeriModePerformValidation, iota.PayloadSelector)
+		assert.NoError(t, err)
+		assert.Equal(t, iota.UInt32ByteSize+len(indexationPayloadData), bytesRead)
+		seriData, err := seri.Serialize(iota.DeSeriModePerformValidation)
+		assert.NoError(t, err)
+		assert.Equal(t, indexationPayloadData, seriData)
+
+		_, _, err = iota.DeserializeFromReader(r, iota.DeSeriModePerformValidation, iota.PayloadSelector)
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("EOF mid-object", func(t *testing.T) {
+		framed := frame(sigTxPayData)
+		for i := 1; i < len(framed); i++ {
+			r := iotest.OneByteReader(bytes.NewReader(framed[:i]))
+			_, _, err := iota.DeserializeFromReader(r, iota.DeSeriModePerformValidation, iota.PayloadSelector)
+			assert.True(t, errors.Is(err, iota.ErrDeserializationNotEnoughData), "unexpected error %v for stream truncated to %d bytes", err, i)
+		}
+	})
+
+	t.Run("reader error", func(t *testing.T) {
+		r := iotest.TimeoutReader(iotest.OneByteReader(bytes.NewReader(stream)))
+		_, _, err := iota.DeserializeFromReader(r, iota.DeSeriModePerformValidation, iota.PayloadSelector)
+		assert.True(t, errors.Is(err, iota.ErrDeserializationNotEnoughData))
+	})
+
+	t.Run("length denotation exceeds max length", func(t *testing.T) {
+		var lengthBytes [iota.UInt32ByteSize]byte
+		binary.LittleEndian.PutUint32(lengthBytes[:], math.MaxUint32)
+		// nothing after the length denotation may be read
+		r := io.MultiReader(bytes.NewReader(lengthBytes[:]), failingReader{})
+		_, _, err := iota.DeserializeFromReader(r, iota.DeSeriModePerformValidation, iota.PayloadSelector)
+		assert.True(t, errors.Is(err, iota.ErrObjectTooLarge), "unexpected error %v", err)
+	})
+
+	t.Run("length denotation exceeds object", func(t *testing.T) {
+		framed := frame(append(append([]byte{}, indexationPayloadData...), 0))
+		_, _, err := iota.DeserializeFromReader(bytes.NewReader(framed), iota.DeSeriModePerformValidation, iota.PayloadSelector)
+		assert.True(t, errors.Is(err, iota.ErrDeserializationNotAllConsumed))
+	})
+}
+
+func TestWriterTo(t *testing.T) {
+	type writerToSerializable interface {
+		iota.Serializable
+		io.WriterTo
+	}
+	type test struct {
+		name   string
+		source writerToSerializable
+	}
+	tests := []test{
+		func() test {
+			addr, _ := randWOTSAddr()
+			return test{"WOTS address", addr}
+		}(),
+		func() test {
+			addr, _ := randEd25519Addr()
+			return test{"Ed25519 address", addr}
+		}(),
+		func() test {
+			addr, _ := randAliasAddr()
+			return test{"alias address", addr}
+		}(),
+		func() test {
+			addr, _ := randNFTAddr()
+			return test{"NFT address", addr}
+		}(),
+		func() test {
+			sig, _ := randEd25519Signature()
+			return test{"Ed25519 signature", sig}
+		}(),
+		func() test {
+			input, _ := randUTXOInput()
+			return test{"UTXO input", input}
+		}(),
+		func() test {
+			input := &iota.TreasuryInput{}
+			copy(input[:], randBytes(iota.MilestoneIDLength))
+			return test{"treasury input", input}
+		}(),
+		func() test {
+			output, _ := randSigLockedSingleOutput(iota.AddressEd25519)
+			return test{"sig locked single output", output}
+		}(),
+		func() test {
+			output, _ := randSigLockedDustAllowanceOutput(iota.DustAllowanceMinimum)
+			return test{"sig locked dust allowance output", output}
+		}(),
+		{"treasury output", &iota.TreasuryOutput{Amount: 1337}},
+		func() test {
+			block, _ := randEd25519SignatureUnlockBlock()
+			return test{"signature unlock block", block}
+		}(),
+		func() test {
+			block, _ := randReferenceUnlockBlock()
+			return test{"reference unlock block", block}
+		}(),
+		func() test {
+			indexation, _ := randIndexationPayload()
+			return test{"indexation payload", indexation}
+		}(),
+		func() test {
+			unTx, _ := randUnsignedTransaction()
+			return test{"unsigned transaction", unTx}
+		}(),
+		func() test {
+			sigTxPayload, _ := randSignedTransactionPayload()
+			return test{"signed transaction payload", sigTxPayload}
+		}(),
+		func() test {
+			msPayload, _ := randMilestonePayload()
+			return test{"milestone payload", msPayload}
+		}(),
+		func() test {
+			receipt, _ := randReceiptPayload(3)
+			return test{"receipt payload", receipt}
+		}(),
+		func() test {
+			treasuryTx, _ := randTreasuryTransaction()
+			return test{"treasury transaction", treasuryTx}
+		}(),
+		func() test {
+			msg, _ := randMessage(iota.IndexationPayloadID)
+			return test{"message", msg}
+		}(),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected, err := tt.source.Serialize(iota.WriteToMode)
+			if !assert.NoError(t, err) {
+				return
+			}
+			var buf bytes.Buffer
+			n, err := tt.source.WriteTo(&buf)
+			assert.NoError(t, err)
+			assert.EqualValues(t, len(expected), n)
+			assert.Equal(t, expected, buf.Bytes())
+		})
+	}
+
+	// validation errors surface before anything is written
+	var buf bytes.Buffer
+	n, err := (&iota.IndexationPayload{}).WriteTo(&buf)
+	assert.True(t, errors.Is(err, iota.ErrIndexationPayloadIndexLengthInvalid), "unexpected error %v", err)
+	assert.Zero(t, n)
+	assert.Zero(t, buf.Len())
+}
+
+func TestDeserializationError_Offset(t *testing.T) {
+	unTx, unTxData := randUnsignedTransaction()
+	inputsLength := iota.StructArrayLengthByteSize + len(unTx.Inputs)*iota.UTXOInputSize
+	firstOutputOffset := iota.TypeDenotationByteSize + iota.UnsignedTransactionFormatVersionByteSize + inputsLength + iota.StructArrayLengthByteSize
+	unTxData[firstOutputOffset] = 99
+
+	_, _, err := iota.DeserializeObject(unTxData, iota.DeSeriModeNoValidation, iota.TypeDenotationUint32, iota.TransactionSelector)
+	assert.True(t, errors.Is(err, iota.ErrUnknownOutputType), "unexpected error %v", err)
+	var deErr *iota.DeserializationError
+	if assert.True(t, errors.As(err, &deErr)) {
+		assert.Equal(t, firstOutputOffset, deErr.Offset)
+	}
+
+	_, input1Data := randUTXOInput()
+	_, input2Data := randUTXOInput()
+	input2Data[0] = 99
+	arrayData := append(append([]byte{2, 0}, input1Data...), input2Data...)
+	_, _, err = iota.DeserializeArrayOfObjects(arrayData, iota.DeSeriModeNoValidation, iota.TypeDenotationByte, iota.InputSelector, nil)
+	assert.True(t, errors.Is(err, iota.ErrUnknownInputType), "unexpected error %v", err)
+	if assert.True(t, errors.As(err, &deErr)) {
+		assert.Equal(t, iota.StructArrayLengthByteSize+len(input1Data), deErr.Offset)
+	}
+}
+
+func TestDeserializeArrayOfObjects_UnknownTypeElementIndex(t *testing.T) {
+	_, input1Data := randUTXOInput()
+	_, input2Data := randUTXOInput()
+	_, input3Data := randUTXOInput()
+	input2Data[0] = 99
+	arrayData := bytes.Join([][]byte{{3, 0}, input1Data, input2Data, input3Data}, nil)
+
+	for _, mode := range []iota.DeSerializationMode{iota.DeSeriModeNoValidation, fullValidation} {
+		seris, bytesRead, err := iota.DeserializeArrayOfObjects(arrayData, mode, iota.TypeDenotationByte, iota.InputSelector, nil)
+		assert.True(t, errors.Is(err, iota.ErrUnknownInputType), "unexpected error %v", err)
+		assert.Contains(t, err.Error(), "element 1")
+		assert.Nil(t, seris)
+		assert.Zero(t, bytesRead)
+	}
+}
+
+// serializes to different bytes on every call, like a form depending on map iteration order
+type nonDeterministicSeri struct {
+	calls byte
+}
+
+func (n *nonDeterministicSeri) Deserialize(data []byte, deSeriMode iota.DeSerializationMode) (int, error) {
+	n.calls = data[0]
+	return iota.OneByte, nil
+}
+
+func (n *nonDeterministicSeri) Serialize(deSeriMode iota.DeSerializationMode) ([]byte, error) {
+	n.calls++
+	return []byte{n.calls}, nil
+}
+
+// drops the second byte of its form when deserializing
+type lossySeri struct {
+	Data [2]byte
+}
+
+func (l *lossySeri) Deserialize(data []byte, deSeriMode iota.DeSerializationMode) (int, error) {
+	l.Data[0] = data[0]
+	return len(l.Data), nil
+}
+
+func (l *lossySeri) Serialize(deSeriMode iota.DeSerializationMode) ([]byte, error) {
+	return l.Data[:], nil
+}
+
+func TestVerifyRoundTrip(t *testing.T) {
+	type test struct {
+		name string
+		seri iota.Serializable
+		err  error
+	}
+	tests := []test{
+		func() test {
+			sigTxPayload, _ := randSignedTransactionPayload()
+			return test{"signed transaction payload", sigTxPayload, nil}
+		}(),
+		func() test {
+			msg, _ := randMessage(iota.MilestonePayloadID)
+			return test{"message with milestone payload", msg, nil}
+		}(),
+		func() test {
+			return test{"different bytes on subsequent calls", &nonDeterministicSeri{}, iota.ErrNonDeterministicSerialization}
+		}(),
+		func() test {
+			return test{"lossy deserialization", &lossySeri{Data: [2]byte{1, 2}}, iota.ErrNonDeterministicSerialization}
+		}(),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := iota.VerifyRoundTrip(tt.seri)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "expected %v, got %v", tt.err, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
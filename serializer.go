@@ -0,0 +1,550 @@
+package iota
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sync"
+)
+
+// bufferPool holds the buffers used during serialization.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// getBuffer returns an empty buffer from the pool.
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+// The max capacity of buffers kept in the pool. Larger buffers are left to the garbage collector
+// so that a single big object doesn't pin its memory in the pool.
+const maxPooledBufferCap = MaxMessageLength
+
+// putBuffer resets the given buffer and returns it to the pool unless it grew beyond maxPooledBufferCap.
+// The buffer and any slice obtained from it must not be used afterwards.
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferCap {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+// Deserializer is a utility to deserialize bytes without manually keeping track of offsets.
+// Every read is bounds checked against the remaining data. Once an error occurred, subsequent
+// reads are no-ops and the first error is returned by Done.
+type Deserializer struct {
+	src    []byte
+	offset int
+	err    error
+}
+
+// NewDeserializer creates a new Deserializer reading from the given data.
+func NewDeserializer(src []byte) *Deserializer {
+	return &Deserializer{src: src}
+}
+
+// ensureAvailable sets an error on the Deserializer if less than the given amount of bytes are left.
+func (d *Deserializer) ensureAvailable(length int, errCtx string) bool {
+	if available := len(d.src) - d.offset; available < length {
+		d.err = fmt.Errorf("%w: unable to deserialize %s, %d bytes are needed but only %d are available", ErrDeserializationNotEnoughData, errCtx, length, available)
+		return false
+	}
+	return true
+}
+
+// Skip skips the given amount of bytes.
+func (d *Deserializer) Skip(skip int, errCtx string) *Deserializer {
+	if d.err != nil || !d.ensureAvailable(skip, errCtx) {
+		return d
+	}
+	d.offset += skip
+	return d
+}
+
+// ReadNum reads a little endian encoded number into dest.
+// dest must be a *byte, *uint16, *uint32 or *uint64.
+func (d *Deserializer) ReadNum(dest interface{}, errCtx string) *Deserializer {
+	if d.err != nil {
+		return d
+	}
+	switch x := dest.(type) {
+	case *byte:
+		if d.ensureAvailable(OneByte, errCtx) {
+			*x = d.src[d.offset]
+			d.offset += OneByte
+		}
+	case *uint16:
+		if d.ensureAvailable(UInt16ByteSize, errCtx) {
+			*x = binary.LittleEndian.Uint16(d.src[d.offset:])
+			d.offset += UInt16ByteSize
+		}
+	case *uint32:
+		if d.ensureAvailable(UInt32ByteSize, errCtx) {
+			*x = binary.LittleEndian.Uint32(d.src[d.offset:])
+			d.offset += UInt32ByteSize
+		}
+	case *uint64:
+		if d.ensureAvailable(UInt64ByteSize, errCtx) {
+			*x = binary.LittleEndian.Uint64(d.src[d.offset:])
+			d.offset += UInt64ByteSize
+		}
+	default:
+		panic(fmt.Sprintf("unsupported number type %T", dest))
+	}
+	return d
+}
+
+// ReadBytes copies len(dest) bytes into dest.
+func (d *Deserializer) ReadBytes(dest []byte, errCtx string) *Deserializer {
+	if d.err != nil || !d.ensureAvailable(len(dest), errCtx) {
+		return d
+	}
+	d.offset += copy(dest, d.src[d.offset:])
+	return d
+}
+
+// ReadVariableBytes reads bytes prefixed by their uint32 length denotation into dest.
+func (d *Deserializer) ReadVariableBytes(dest *[]byte, errCtx string) *Deserializer {
+	var length uint32
+	if d.ReadNum(&length, errCtx); d.err != nil {
+		return d
+	}
+	if !d.ensureAvailable(int(length), errCtx) {
+		return d
+	}
+	*dest = make([]byte, length)
+	return d.ReadBytes(*dest, errCtx)
+}
+
+// ReadString reads a string prefixed by its uint16 length denotation into dest.
+func (d *Deserializer) ReadString(dest *string, errCtx string) *Deserializer {
+	if d.err != nil {
+		return d
+	}
+	str, strBytesRead, err := ReadStringFromBytes(d.src[d.offset:])
+	if err != nil {
+		d.err = fmt.Errorf("unable to deserialize %s: %w", errCtx, err)
+		return d
+	}
+	*dest = str
+	d.offset += strBytesRead
+	return d
+}
+
+// ReadObject reads a Serializable denoted by its type into dest.
+func (d *Deserializer) ReadObject(dest *Serializable, deSeriMode DeSerializationMode, typeDen TypeDenotationType, serSel SerializableSelectorFunc, errCtx string) *Deserializer {
+	if d.err != nil {
+		return d
+	}
+	seri, seriBytesRead, err := DeserializeObject(d.src[d.offset:], deSeriMode, typeDen, serSel)
+	if err != nil {
+		d.err = deserializationErrorAt(d.offset, err, "unable to deserialize "+errCtx)
+		return d
+	}
+	*dest = seri
+	d.offset += seriBytesRead
+	return d
+}
+
+// ReadSliceOfObjects reads an array of Serializables prefixed by their count into dest.
+// The given ArrayRules are checked if the DeSeriModePerformValidation mode is given.
+func (d *Deserializer) ReadSliceOfObjects(dest *Serializables, deSeriMode DeSerializationMode, typeDen TypeDenotationType, serSel SerializableSelectorFunc, arrayRules *ArrayRules, errCtx string) *Deserializer {
+	if d.err != nil {
+		return d
+	}
+	seris, serisBytesRead, err := DeserializeArrayOfObjects(d.src[d.offset:], deSeriMode, typeDen, serSel, arrayRules)
+	if err != nil {
+		d.err = deserializationErrorAt(d.offset, err, "unable to deserialize "+errCtx)
+		return d
+	}
+	*dest = seris
+	d.offset += serisBytesRead
+	return d
+}
+
+// ReadSliceOfArraysOf32Bytes reads an array of 32 byte arrays prefixed by their uint16 count into dest.
+// The given ArrayRules are checked if the DeSeriModePerformValidation mode is given.
+func (d *Deserializer) ReadSliceOfArraysOf32Bytes(dest *SliceOfArraysOf32Bytes, deSeriMode DeSerializationMode, arrayRules *ArrayRules, errCtx string) *Deserializer {
+	const elementSize = 32
+	count, ok := d.readFixedSizeElementsCount(elementSize, deSeriMode, arrayRules, errCtx)
+	if !ok {
+		return d
+	}
+	arrays := make(SliceOfArraysOf32Bytes, count)
+	for i := range arrays {
+		d.offset += copy(arrays[i][:], d.src[d.offset:])
+	}
+	*dest = arrays
+	return d
+}
+
+// ReadSliceOfArraysOf64Bytes reads an array of 64 byte arrays prefixed by their uint16 count into dest.
+// The given ArrayRules are checked if the DeSeriModePerformValidation mode is given.
+func (d *Deserializer) ReadSliceOfArraysOf64Bytes(dest *SliceOfArraysOf64Bytes, deSeriMode DeSerializationMode, arrayRules *ArrayRules, errCtx string) *Deserializer {
+	const elementSize = 64
+	count, ok := d.readFixedSizeElementsCount(elementSize, deSeriMode, arrayRules, errCtx)
+	if !ok {
+		return d
+	}
+	arrays := make(SliceOfArraysOf64Bytes, count)
+	for i := range arrays {
+		d.offset += copy(arrays[i][:], d.src[d.offset:])
+	}
+	*dest = arrays
+	return d
+}
+
+// SkipSliceOfArrays skips an array of byte arrays of the given size prefixed by their uint16 count.
+// The given ArrayRules are checked if the DeSeriModePerformValidation mode is given.
+func (d *Deserializer) SkipSliceOfArrays(elementSize int, deSeriMode DeSerializationMode, arrayRules *ArrayRules, errCtx string) *Deserializer {
+	count, ok := d.readFixedSizeElementsCount(elementSize, deSeriMode, arrayRules, errCtx)
+	if !ok {
+		return d
+	}
+	d.offset += count * elementSize
+	return d
+}
+
+// readFixedSizeElementsCount reads the uint16 count of an array of fixed size elements, checks the given ArrayRules
+// against the elements and ensures that the elements are available. The offset is left at the first element.
+func (d *Deserializer) readFixedSizeElementsCount(elementSize int, deSeriMode DeSerializationMode, arrayRules *ArrayRules, errCtx string) (int, bool) {
+	var count uint16
+	if d.ReadNum(&count, errCtx); d.err != nil {
+		return 0, false
+	}
+	if arrayRules != nil && deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := arrayRules.CheckBounds(count); err != nil {
+			d.err = fmt.Errorf("unable to deserialize %s: %w", errCtx, err)
+			return 0, false
+		}
+	}
+	if !d.ensureAvailable(int(count)*elementSize, errCtx) {
+		return 0, false
+	}
+	if lexicalOrderValidator := arrayRules.lexicalOrderValidatorFor(deSeriMode); lexicalOrderValidator != nil {
+		for i := 0; i < int(count); i++ {
+			elementOffset := d.offset + i*elementSize
+			if err := lexicalOrderValidator(i, d.src[elementOffset:elementOffset+elementSize]); err != nil {
+				d.err = fmt.Errorf("unable to deserialize %s: %w", errCtx, err)
+				return 0, false
+			}
+		}
+	}
+	return int(count), true
+}
+
+// ReadPayload reads a payload prefixed by its length denotation into dest.
+// dest is set to nil if the payload length is zero. A maxPayloadLength of 0 doesn't bound the payload length.
+func (d *Deserializer) ReadPayload(dest *Serializable, deSeriMode DeSerializationMode, maxPayloadLength uint32, errCtx string) *Deserializer {
+	if d.err != nil {
+		return d
+	}
+	payload, payloadBytesRead, err := ParsePayload(d.src[d.offset:], deSeriMode, maxPayloadLength)
+	if err != nil {
+		d.err = shiftDeserializationError(d.offset, err, "unable to deserialize "+errCtx)
+		return d
+	}
+	*dest = payload
+	d.offset += payloadBytesRead
+	return d
+}
+
+// SkipVariableBytes skips bytes prefixed by their uint32 length denotation.
+func (d *Deserializer) SkipVariableBytes(errCtx string) *Deserializer {
+	var length uint32
+	if d.ReadNum(&length, errCtx); d.err != nil {
+		return d
+	}
+	return d.Skip(int(length), errCtx)
+}
+
+// SkipString skips a string prefixed by its uint16 length denotation.
+func (d *Deserializer) SkipString(errCtx string) *Deserializer {
+	var length uint16
+	if d.ReadNum(&length, errCtx); d.err != nil {
+		return d
+	}
+	return d.Skip(int(length), errCtx)
+}
+
+// SkipObject skips a Serializable denoted by its type after checking the consistency of its bytes.
+// See ValidateBytes for what is checked.
+func (d *Deserializer) SkipObject(deSeriMode DeSerializationMode, typeDen TypeDenotationType, serSel SerializableSelectorFunc, errCtx string) *Deserializer {
+	if d.err != nil {
+		return d
+	}
+	seriBytesConsumed, err := validateObjectBytes(d.src[d.offset:], deSeriMode, typeDen, serSel)
+	if err != nil {
+		d.err = fmt.Errorf("unable to validate %s: %w", errCtx, err)
+		return d
+	}
+	d.offset += seriBytesConsumed
+	return d
+}
+
+// SkipSliceOfObjects skips an array of Serializables prefixed by their count after checking the consistency of their bytes.
+// The given ArrayRules are checked if the DeSeriModePerformValidation mode is given.
+func (d *Deserializer) SkipSliceOfObjects(deSeriMode DeSerializationMode, typeDen TypeDenotationType, serSel SerializableSelectorFunc, arrayRules *ArrayRules, errCtx string) *Deserializer {
+	if d.err != nil {
+		return d
+	}
+	serisBytesConsumed, err := validateArrayOfObjectsBytes(d.src[d.offset:], deSeriMode, typeDen, serSel, arrayRules)
+	if err != nil {
+		d.err = fmt.Errorf("unable to validate %s: %w", errCtx, err)
+		return d
+	}
+	d.offset += serisBytesConsumed
+	return d
+}
+
+// SkipPayload skips a payload prefixed by its length denotation after checking the consistency of its bytes.
+// A maxPayloadLength of 0 doesn't bound the payload length.
+func (d *Deserializer) SkipPayload(deSeriMode DeSerializationMode, maxPayloadLength uint32, errCtx string) *Deserializer {
+	if d.err != nil {
+		return d
+	}
+	payloadBytesConsumed, err := validatePayloadBytes(d.src[d.offset:], deSeriMode, maxPayloadLength)
+	if err != nil {
+		d.err = fmt.Errorf("unable to validate %s: %w", errCtx, err)
+		return d
+	}
+	d.offset += payloadBytesConsumed
+	return d
+}
+
+// AbortIf calls the given function if no error occurred yet and aborts the deserialization if it returns an error.
+func (d *Deserializer) AbortIf(f func() error) *Deserializer {
+	if d.err != nil {
+		return d
+	}
+	if err := f(); err != nil {
+		d.err = err
+	}
+	return d
+}
+
+// Done finishes the deserialization and returns the amount of bytes consumed
+// or the first error which occurred during deserialization.
+func (d *Deserializer) Done() (int, error) {
+	if d.err != nil {
+		return 0, d.err
+	}
+	return d.offset, nil
+}
+
+// Serializer is a utility to serialize into bytes without manually handling buffer errors.
+// Once an error occurred, subsequent writes are no-ops and the first error is returned by Serialize.
+// The Serializer writes into a pooled buffer which is handed back by Serialize, so it must not be used afterwards.
+type Serializer struct {
+	buf *bytes.Buffer
+	// scratch space for encoding numbers
+	numBuf [UInt64ByteSize]byte
+	err    error
+}
+
+// NewSerializer creates a new Serializer.
+func NewSerializer() *Serializer {
+	return &Serializer{buf: getBuffer()}
+}
+
+// WriteNum writes the given number in little endian encoding.
+// v must be a byte, uint16, uint32 or uint64, anything else is written via binary.Write.
+func (s *Serializer) WriteNum(v interface{}, errCtx string) *Serializer {
+	if s.err != nil {
+		return s
+	}
+	var numBytes []byte
+	switch x := v.(type) {
+	case byte:
+		s.numBuf[0] = x
+		numBytes = s.numBuf[:OneByte]
+	case uint16:
+		binary.LittleEndian.PutUint16(s.numBuf[:], x)
+		numBytes = s.numBuf[:UInt16ByteSize]
+	case uint32:
+		binary.LittleEndian.PutUint32(s.numBuf[:], x)
+		numBytes = s.numBuf[:UInt32ByteSize]
+	case uint64:
+		binary.LittleEndian.PutUint64(s.numBuf[:], x)
+		numBytes = s.numBuf[:UInt64ByteSize]
+	default:
+		if err := binary.Write(s.buf, binary.LittleEndian, v); err != nil {
+			s.err = fmt.Errorf("unable to serialize %s: %w", errCtx, err)
+		}
+		return s
+	}
+	return s.WriteBytes(numBytes, errCtx)
+}
+
+// WriteBytes writes the given bytes.
+func (s *Serializer) WriteBytes(data []byte, errCtx string) *Serializer {
+	if s.err != nil {
+		return s
+	}
+	if _, err := s.buf.Write(data); err != nil {
+		s.err = fmt.Errorf("unable to serialize %s: %w", errCtx, err)
+	}
+	return s
+}
+
+// WriteVariableBytes writes the given bytes prefixed by their uint32 length denotation.
+func (s *Serializer) WriteVariableBytes(data []byte, errCtx string) *Serializer {
+	return s.WriteNum(uint32(len(data)), errCtx).WriteBytes(data, errCtx)
+}
+
+// WriteString writes the given string prefixed by its uint16 length denotation.
+func (s *Serializer) WriteString(str string, errCtx string) *Serializer {
+	return s.WriteNum(uint16(len(str)), errCtx).WriteBytes([]byte(str), errCtx)
+}
+
+// grow grows the buffer by the hinted sizes of the given Serializables which implement SizeHinter.
+// Serializables without a hint are left to the buffer's dynamic growth.
+func (s *Serializer) grow(extra int, seris ...Serializable) {
+	for _, seri := range seris {
+		if sizeHinter, ok := seri.(SizeHinter); ok {
+			extra += sizeHinter.SerializedSize()
+		}
+	}
+	s.buf.Grow(extra)
+}
+
+// WriteObject writes the serialized form of the given Serializable.
+func (s *Serializer) WriteObject(seri Serializable, deSeriMode DeSerializationMode, errCtx string) *Serializer {
+	if s.err != nil {
+		return s
+	}
+	s.grow(0, seri)
+	seriBytes, err := seri.Serialize(deSeriMode)
+	if err != nil {
+		s.err = fmt.Errorf("unable to serialize %s: %w", errCtx, err)
+		return s
+	}
+	return s.WriteBytes(seriBytes, errCtx)
+}
+
+// WriteSliceOfObjects writes the given Serializables prefixed by their uint16 count.
+// An optional LexicalOrderFunc can be passed in to check the order of the serialized elements.
+func (s *Serializer) WriteSliceOfObjects(seris Serializables, deSeriMode DeSerializationMode, lexicalOrderValidator LexicalOrderFunc, errCtx string) *Serializer {
+	if s.err != nil {
+		return s
+	}
+	s.grow(StructArrayLengthByteSize, seris...)
+	if s.WriteNum(uint16(len(seris)), errCtx); s.err != nil {
+		return s
+	}
+	for i, seri := range seris {
+		seriBytes, err := seri.Serialize(deSeriMode)
+		if err != nil {
+			s.err = fmt.Errorf("unable to serialize %s at index %d: %w", errCtx, i, err)
+			return s
+		}
+		if lexicalOrderValidator != nil {
+			if err := lexicalOrderValidator(i, seriBytes); err != nil {
+				s.err = fmt.Errorf("unable to serialize %s: %w", errCtx, err)
+				return s
+			}
+		}
+		if s.WriteBytes(seriBytes, errCtx); s.err != nil {
+			return s
+		}
+	}
+	return s
+}
+
+// WriteSliceOfArraysOf32Bytes writes the given 32 byte arrays prefixed by their uint16 count.
+// The given ArrayRules are checked if the DeSeriModePerformValidation mode is given.
+func (s *Serializer) WriteSliceOfArraysOf32Bytes(arrays SliceOfArraysOf32Bytes, deSeriMode DeSerializationMode, arrayRules *ArrayRules, errCtx string) *Serializer {
+	elements := make([][]byte, len(arrays))
+	for i := range arrays {
+		elements[i] = arrays[i][:]
+	}
+	return s.writeFixedSizeElements(elements, deSeriMode, arrayRules, errCtx)
+}
+
+// WriteSliceOfArraysOf64Bytes writes the given 64 byte arrays prefixed by their uint16 count.
+// The given ArrayRules are checked if the DeSeriModePerformValidation mode is given.
+func (s *Serializer) WriteSliceOfArraysOf64Bytes(arrays SliceOfArraysOf64Bytes, deSeriMode DeSerializationMode, arrayRules *ArrayRules, errCtx string) *Serializer {
+	elements := make([][]byte, len(arrays))
+	for i := range arrays {
+		elements[i] = arrays[i][:]
+	}
+	return s.writeFixedSizeElements(elements, deSeriMode, arrayRules, errCtx)
+}
+
+// writeFixedSizeElements writes the given elements prefixed by their uint16 count.
+func (s *Serializer) writeFixedSizeElements(elements [][]byte, deSeriMode DeSerializationMode, arrayRules *ArrayRules, errCtx string) *Serializer {
+	if s.err != nil {
+		return s
+	}
+	if arrayRules != nil && deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := arrayRules.CheckBounds(uint16(len(elements))); err != nil {
+			s.err = fmt.Errorf("unable to serialize %s: %w", errCtx, err)
+			return s
+		}
+	}
+	if lexicalOrderValidator := arrayRules.lexicalOrderValidatorFor(deSeriMode); lexicalOrderValidator != nil {
+		for i, element := range elements {
+			if err := lexicalOrderValidator(i, element); err != nil {
+				s.err = fmt.Errorf("unable to serialize %s: %w", errCtx, err)
+				return s
+			}
+		}
+	}
+	if s.WriteNum(uint16(len(elements)), errCtx); s.err != nil {
+		return s
+	}
+	for _, element := range elements {
+		if s.WriteBytes(element, errCtx); s.err != nil {
+			return s
+		}
+	}
+	return s
+}
+
+// WritePayload writes the given payload prefixed by its uint32 length denotation.
+// A nil payload is written as a zero length denotation.
+func (s *Serializer) WritePayload(payload Serializable, deSeriMode DeSerializationMode, errCtx string) *Serializer {
+	if s.err != nil {
+		return s
+	}
+	if payload == nil {
+		return s.WriteNum(uint32(0), errCtx)
+	}
+	payloadBytes, err := payload.Serialize(deSeriMode)
+	if err != nil {
+		s.err = fmt.Errorf("unable to serialize %s: %w", errCtx, err)
+		return s
+	}
+	return s.WriteVariableBytes(payloadBytes, errCtx)
+}
+
+// AbortIf calls the given function if no error occurred yet and aborts the serialization if it returns an error.
+func (s *Serializer) AbortIf(f func() error) *Serializer {
+	if s.err != nil {
+		return s
+	}
+	if err := f(); err != nil {
+		s.err = err
+	}
+	return s
+}
+
+// Serialize finishes the serialization and returns the serialized bytes
+// or the first error which occurred during serialization.
+// The returned bytes are a copy, as the underlying buffer is returned to the pool in either case.
+func (s *Serializer) Serialize() ([]byte, error) {
+	defer func() {
+		putBuffer(s.buf)
+		s.buf = nil
+	}()
+	if s.err != nil {
+		return nil, s.err
+	}
+	data := make([]byte, s.buf.Len())
+	copy(data, s.buf.Bytes())
+	return data, nil
+}
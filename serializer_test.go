@@ -0,0 +1,108 @@
+package iota_test
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/luca-moser/iota"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSerializerDeserializer(t *testing.T) {
+	originA := randA()
+	originObjs := iota.Serializables{randA(), randB()}
+	originData := randBytes(20)
+
+	data, err := iota.NewSerializer().
+		WriteNum(uint16(1337), "num").
+		WriteBytes([]byte{1, 2, 3}, "bytes").
+		WriteVariableBytes(originData, "variable bytes").
+		WriteString("寿司", "string").
+		WriteObject(originA, iota.DeSeriModePerformValidation, "object").
+		WriteSliceOfObjects(originObjs, iota.DeSeriModePerformValidation, nil, "objects").
+		Serialize()
+	assert.NoError(t, err)
+
+	var num uint16
+	var threeBytes [3]byte
+	var variableData []byte
+	var str string
+	var a iota.Serializable
+	var objs iota.Serializables
+	bytesRead, err := iota.NewDeserializer(data).
+		ReadNum(&num, "num").
+		ReadBytes(threeBytes[:], "bytes").
+		ReadVariableBytes(&variableData, "variable bytes").
+		ReadString(&str, "string").
+		ReadObject(&a, iota.DeSeriModePerformValidation, iota.TypeDenotationByte, DummyTypeSelector, "object").
+		ReadSliceOfObjects(&objs, iota.DeSeriModePerformValidation, iota.TypeDenotationByte, DummyTypeSelector, nil, "objects").
+		Done()
+	assert.NoError(t, err)
+	assert.Equal(t, len(data), bytesRead)
+	assert.EqualValues(t, 1337, num)
+	assert.Equal(t, [3]byte{1, 2, 3}, threeBytes)
+	assert.Equal(t, originData, variableData)
+	assert.Equal(t, "寿司", str)
+	assert.EqualValues(t, originA, a)
+	assert.EqualValues(t, originObjs, objs)
+}
+
+func TestDeserializer_NotEnoughData(t *testing.T) {
+	var num uint64
+	var b [10]byte
+	called := false
+	_, err := iota.NewDeserializer([]byte{1, 2, 3}).
+		ReadBytes(b[:], "bytes").
+		ReadNum(&num, "num").
+		AbortIf(func() error {
+			called = true
+			return nil
+		}).
+		Done()
+	assert.True(t, errors.Is(err, iota.ErrDeserializationNotEnoughData))
+	assert.False(t, called)
+}
+
+func TestDeserializer_AbortIf(t *testing.T) {
+	errAbort := errors.New("abort")
+	var num uint16
+	_, err := iota.NewDeserializer([]byte{1, 0, 2, 0}).
+		ReadNum(&num, "num").
+		AbortIf(func() error {
+			return errAbort
+		}).
+		ReadNum(&num, "num").
+		Done()
+	assert.True(t, errors.Is(err, errAbort))
+	assert.EqualValues(t, 1, num)
+}
+
+func TestSerializer_PooledBuffers(t *testing.T) {
+	first, err := iota.NewSerializer().WriteNum(uint64(1), "first").WriteBytes([]byte{1, 2, 3}, "first").Serialize()
+	assert.NoError(t, err)
+	firstCopy := append([]byte(nil), first...)
+
+	// a failed serialization returns its buffer to the pool too
+	_, err = iota.NewSerializer().WriteNum(uint16(2), "second").AbortIf(func() error { return iota.ErrInvalidBytes }).Serialize()
+	assert.True(t, errors.Is(err, iota.ErrInvalidBytes))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				data, err := iota.NewSerializer().WriteNum(uint32(i), "num").WriteBytes(bytes.Repeat([]byte{byte(i)}, j), "bytes").Serialize()
+				assert.NoError(t, err)
+				assert.Len(t, data, iota.UInt32ByteSize+j)
+				assert.Equal(t, bytes.Repeat([]byte{byte(i)}, j), data[iota.UInt32ByteSize:])
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// the bytes returned earlier didn't get overwritten by the reuse of the buffer
+	assert.Equal(t, firstCopy, first)
+}
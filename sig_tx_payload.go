@@ -1,10 +1,13 @@
 package iota
 
 import (
-	"bytes"
+	"crypto/ed25519"
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/crc32"
+	"io"
 )
 
 const (
@@ -16,33 +19,58 @@ const (
 	MinOutputsCount = 1
 
 	SignedTransactionPayloadMinSize = UInt32ByteSize
+
+	// The length of the CRC32 checksum trailing a checksummed transaction.
+	TransactionChecksumLength = UInt32ByteSize
 )
 
 var (
-	ErrMinInputsNotReached             = errors.New(fmt.Sprintf("min %d input(s) are required within a transaction", MinInputsCount))
-	ErrMaxInputsExceeded               = errors.New(fmt.Sprintf("max %d input(s) are allowed within a transaction", MaxInputsCount))
-	ErrMinOutputsNotReached            = errors.New(fmt.Sprintf("min %d output(s) are required within a transaction", MinOutputsCount))
-	ErrMaxOutputsExceeded              = errors.New(fmt.Sprintf("max %d output(s) are allowed within a transaction", MaxOutputsCount))
+	ErrMinInputsNotReached             = errors.New("min amount of inputs within a transaction not reached")
+	ErrMaxInputsExceeded               = errors.New("max amount of inputs within a transaction exceeded")
+	ErrMinOutputsNotReached            = errors.New("min amount of outputs within a transaction not reached")
+	ErrMaxOutputsExceeded              = errors.New("max amount of outputs within a transaction exceeded")
 	ErrUnlockBlocksMustMatchInputCount = errors.New("the count of unlock blocks must match the inputs of the transaction")
+	ErrInputAddressesMustMatchInputs   = errors.New("the count of input addresses must match the inputs of the transaction")
+	ErrMissingSigningKey               = errors.New("no private key for the given address")
+	ErrSigningKeyAddressMismatch       = errors.New("private key doesn't belong to the given address")
+	ErrChecksumMismatch                = errors.New("checksum doesn't match the transaction")
+	ErrUnlockBlockAddressMismatch      = errors.New("the public key of the unlock block doesn't belong to the address of the input")
+
+	// The max amount of inputs within a transaction. Applications on networks with different
+	// parameters can adjust it, it is read every time a transaction is (de)serialized.
+	MaxInputCount uint16 = MaxInputsCount
+	// The max amount of outputs within a transaction. Applications on networks with different
+	// parameters can adjust it, it is read every time a transaction is (de)serialized.
+	MaxOutputCount uint16 = MaxOutputsCount
+)
 
-	inputsArrayBound = ArrayRules{
+// inputsArrayRules returns the ArrayRules for the inputs of a transaction built from MaxInputCount.
+func inputsArrayRules() *ArrayRules {
+	return &ArrayRules{
 		Min:                         MinInputsCount,
-		Max:                         MaxInputsCount,
+		Max:                         MaxInputCount,
 		MinErr:                      ErrMinInputsNotReached,
 		MaxErr:                      ErrMaxInputsExceeded,
 		ElementBytesLexicalOrder:    true,
 		ElementBytesLexicalOrderErr: ErrInputsOrderViolatesLexicalOrder,
+		MinElementSize:              TreasuryInputSize, // the smallest input
+		Context:                     "inputs",
 	}
+}
 
-	outputsArrayBound = ArrayRules{
-		Min:                         MinInputsCount,
-		Max:                         MaxInputsCount,
+// outputsArrayRules returns the ArrayRules for the outputs of a transaction built from MaxOutputCount.
+func outputsArrayRules() *ArrayRules {
+	return &ArrayRules{
+		Min:                         MinOutputsCount,
+		Max:                         MaxOutputCount,
 		MinErr:                      ErrMinOutputsNotReached,
 		MaxErr:                      ErrMaxOutputsExceeded,
 		ElementBytesLexicalOrder:    true,
 		ElementBytesLexicalOrderErr: ErrOutputsOrderViolatesLexicalOrder,
+		MinElementSize:              TreasuryOutputSize, // the smallest output
+		Context:                     "outputs",
 	}
-)
+}
 
 // SignedTransactionPayload is a transaction with its inputs, outputs and unlock blocks.
 type SignedTransactionPayload struct {
@@ -60,83 +88,327 @@ func (s *SignedTransactionPayload) Deserialize(data []byte, deSeriMode DeSeriali
 		}
 	}
 
-	// skip payload type
-	bytesReadTotal := TypeDenotationByteSize
-	data = data[TypeDenotationByteSize:]
-
-	tx, txBytesRead, err := DeserializeObject(data, deSeriMode, TypeDenotationByte, TransactionSelector)
-	if err != nil {
-		return 0, err
-	}
-	bytesReadTotal += txBytesRead
-	s.Transaction = tx
+	d := NewDeserializer(data).
+		Skip(TypeDenotationByteSize, "signed transaction payload type").
+		ReadObject(&s.Transaction, deSeriMode, TypeDenotationByte, TransactionSelector, "transaction")
 
 	// TODO: tx must be an unsigned tx but might be something else in the future
-	inputCount := uint16(len(tx.(*UnsignedTransaction).Inputs))
-
-	// advance to unlock blocks
-	data = data[txBytesRead:]
-	unlockBlocks, unlockBlocksByteRead, err := DeserializeArrayOfObjects(data, deSeriMode, TypeDenotationByte, UnlockBlockSelector, &ArrayRules{
-		Min:    inputCount,
-		Max:    inputCount,
-		MinErr: ErrUnlockBlocksMustMatchInputCount,
-		MaxErr: ErrUnlockBlocksMustMatchInputCount,
-	})
-	if err != nil {
-		return 0, err
+	var inputCount uint16
+	if unsignedTx, isUnsignedTx := s.Transaction.(*UnsignedTransaction); isUnsignedTx {
+		inputCount = uint16(len(unsignedTx.Inputs))
 	}
-	bytesReadTotal += unlockBlocksByteRead
 
+	return d.
+		ReadSliceOfObjects(&s.UnlockBlocks, deSeriMode, TypeDenotationByte, UnlockBlockSelector, &ArrayRules{
+			Min:            inputCount,
+			Max:            inputCount,
+			MinErr:         ErrUnlockBlocksMustMatchInputCount,
+			MaxErr:         ErrUnlockBlocksMustMatchInputCount,
+			MinElementSize: ReferenceUnlockBlockSize,
+			Context:        "unlock blocks",
+		}, "unlock blocks").
+		AbortIf(func() error {
+			if deSeriMode.HasMode(DeSeriModePerformValidation) {
+				return ValidateUnlockBlocks(s.UnlockBlocks, UnlockBlocksSigUniqueAndRefValidator())
+			}
+			return nil
+		}).
+		Done()
+}
+
+func (s *SignedTransactionPayload) ValidateBytes(data []byte, deSeriMode DeSerializationMode) (int, error) {
 	if deSeriMode.HasMode(DeSeriModePerformValidation) {
-		if err := ValidateUnlockBlocks(unlockBlocks, UnlockBlocksSigUniqueAndRefValidator()); err != nil {
+		if err := checkMinByteLength(SignedTransactionPayloadMinSize, len(data)); err != nil {
 			return 0, err
 		}
+		if err := checkType(data, SignedTransactionPayloadID); err != nil {
+			return 0, fmt.Errorf("invalid signed transaction payload bytes: %w", err)
+		}
 	}
 
-	s.UnlockBlocks = unlockBlocks
+	d := NewDeserializer(data).Skip(TypeDenotationByteSize, "signed transaction payload type")
+
+	txOffset := d.offset
+	d.SkipObject(deSeriMode, TypeDenotationByte, TransactionSelector, "transaction")
 
-	return bytesReadTotal, nil
+	// TODO: tx must be an unsigned tx but might be something else in the future
+	var inputCount uint16
+	if _, err := d.Done(); err == nil && binary.LittleEndian.Uint32(data[txOffset:]) == TransactionUnsigned {
+		inputCount = binary.LittleEndian.Uint16(data[txOffset+TypeDenotationByteSize+UnsignedTransactionFormatVersionByteSize:])
+	}
+
+	return d.
+		SkipSliceOfObjects(deSeriMode, TypeDenotationByte, UnlockBlockSelector, &ArrayRules{
+			Min:            inputCount,
+			Max:            inputCount,
+			MinErr:         ErrUnlockBlocksMustMatchInputCount,
+			MaxErr:         ErrUnlockBlocksMustMatchInputCount,
+			MinElementSize: ReferenceUnlockBlockSize,
+			Context:        "unlock blocks",
+		}, "unlock blocks").
+		Done()
 }
 
 func (s *SignedTransactionPayload) Serialize(deSeriMode DeSerializationMode) ([]byte, error) {
 	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if unsignedTx, isUnsignedTx := s.Transaction.(*UnsignedTransaction); isUnsignedTx && len(unsignedTx.Inputs) != len(s.UnlockBlocks) {
+			return nil, fmt.Errorf("%w: %d inputs but %d unlock blocks", ErrUnlockBlocksMustMatchInputCount, len(unsignedTx.Inputs), len(s.UnlockBlocks))
+		}
 		if err := ValidateUnlockBlocks(s.UnlockBlocks, UnlockBlocksSigUniqueAndRefValidator()); err != nil {
 			return nil, err
 		}
 	}
 
-	var b bytes.Buffer
-	if err := binary.Write(&b, binary.LittleEndian, SignedTransactionPayloadID); err != nil {
-		return nil, err
+	return NewSerializer().
+		WriteNum(SignedTransactionPayloadID, "signed transaction payload type").
+		WriteObject(s.Transaction, deSeriMode, "transaction").
+		WriteSliceOfObjects(s.UnlockBlocks, deSeriMode, nil, "unlock blocks").
+		Serialize()
+}
+
+// WriteTo serializes the SignedTransactionPayload with WriteToMode and writes it to w, implementing io.WriterTo.
+func (s *SignedTransactionPayload) WriteTo(w io.Writer) (int64, error) {
+	return writeSerializableTo(w, s)
+}
+
+// SerializeInto writes the serialized form of the signed transaction payload into buf and returns the amount of bytes written.
+// It performs the same validations as Serialize.
+func (s *SignedTransactionPayload) SerializeInto(buf []byte, deSeriMode DeSerializationMode) (int, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if unsignedTx, isUnsignedTx := s.Transaction.(*UnsignedTransaction); isUnsignedTx && len(unsignedTx.Inputs) != len(s.UnlockBlocks) {
+			return 0, fmt.Errorf("%w: %d inputs but %d unlock blocks", ErrUnlockBlocksMustMatchInputCount, len(unsignedTx.Inputs), len(s.UnlockBlocks))
+		}
+		if err := ValidateUnlockBlocks(s.UnlockBlocks, UnlockBlocksSigUniqueAndRefValidator()); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := checkSerializationBufferSize(TypeDenotationByteSize, len(buf)); err != nil {
+		return 0, err
 	}
+	binary.LittleEndian.PutUint32(buf, SignedTransactionPayloadID)
+	offset := TypeDenotationByteSize
 
-	// write transaction
-	txBytes, err := s.Transaction.Serialize(deSeriMode)
+	txBytesWritten, err := SerializeInto(s.Transaction, buf[offset:], deSeriMode)
 	if err != nil {
-		return nil, err
+		return 0, fmt.Errorf("unable to serialize transaction: %w", err)
 	}
-	if _, err := b.Write(txBytes); err != nil {
-		return nil, err
+	offset += txBytesWritten
+
+	unlockBlocksBytesWritten, err := serializeSliceOfObjectsInto(s.UnlockBlocks, buf[offset:], deSeriMode, nil, nil, "unlock blocks")
+	if err != nil {
+		return 0, err
 	}
+	return offset + unlockBlocksBytesWritten, nil
+}
 
-	// write unlock blocks and count
-	if err := binary.Write(&b, binary.LittleEndian, uint16(len(s.UnlockBlocks))); err != nil {
-		return nil, err
+// Resign replaces all unlock blocks with the ones produced by UnsignedTransaction.Sign,
+// but with the private keys keyed by the raw bytes of their address.
+// The unlock blocks are left unchanged if an error is returned.
+func (s *SignedTransactionPayload) Resign(keys map[string]ed25519.PrivateKey, inputAddresses []Serializable) error {
+	unsignedTx, ok := s.Transaction.(*UnsignedTransaction)
+	if !ok {
+		return fmt.Errorf("%w: can only resign unsigned transactions but is %T", ErrUnknownTransactionType, s.Transaction)
+	}
+	unlockBlocks, err := unsignedTx.sign(inputAddresses, func(addr *Ed25519Address) (ed25519.PrivateKey, bool) {
+		prvKey, has := keys[string(addr[:])]
+		return prvKey, has
+	})
+	if err != nil {
+		return err
+	}
+
+	s.UnlockBlocks = unlockBlocks
+	return nil
+}
+
+// VerifySignatures recomputes the essence hash of the transaction and verifies that every input is unlocked by a valid
+// signature of the key behind the address of the output it references. inputAddresses must hold the address of the output
+// referenced by the input at the same index. Reference unlock blocks are resolved to the signature unlock block they reference.
+// An error wrapping ErrSignatureInvalid is returned if a signature doesn't verify and one wrapping ErrUnlockBlockAddressMismatch
+// if the public key of an input's signature doesn't belong to the input's address.
+func (s *SignedTransactionPayload) VerifySignatures(inputAddresses []Serializable) error {
+	unsignedTx, ok := s.Transaction.(*UnsignedTransaction)
+	if !ok {
+		return fmt.Errorf("%w: can only verify unsigned transactions but is %T", ErrUnknownTransactionType, s.Transaction)
+	}
+	if len(inputAddresses) != len(unsignedTx.Inputs) {
+		return fmt.Errorf("%w: %d inputs but %d addresses", ErrInputAddressesMustMatchInputs, len(unsignedTx.Inputs), len(inputAddresses))
+	}
+	if len(s.UnlockBlocks) != len(unsignedTx.Inputs) {
+		return fmt.Errorf("%w: %d inputs but %d unlock blocks", ErrUnlockBlocksMustMatchInputCount, len(unsignedTx.Inputs), len(s.UnlockBlocks))
+	}
+
+	essenceHash, err := unsignedTx.EssenceHash()
+	if err != nil {
+		return err
 	}
-	for i := range s.UnlockBlocks {
-		unlockBlockSer, err := s.UnlockBlocks[i].Serialize(deSeriMode)
+
+	unlockBlocks := UnlockBlocks(s.UnlockBlocks)
+	verified := map[*SignatureUnlockBlock]struct{}{}
+	for i, addr := range inputAddresses {
+		sigUnlockBlock, err := unlockBlocks.SignatureForInput(i)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		edSig, ok := sigUnlockBlock.Signature.(*Ed25519Signature)
+		if !ok {
+			return fmt.Errorf("%w: can only verify Ed25519 signatures but the unlock block of input %d holds %T", ErrUnknownSignatureType, i, sigUnlockBlock.Signature)
+		}
+		edAddr, ok := addr.(*Ed25519Address)
+		if !ok {
+			return fmt.Errorf("%w: can only verify for Ed25519 addresses but input %d has address of type %T", ErrUnknownAddrType, i, addr)
+		}
+		if !edSig.AddressMatches(edAddr) {
+			return fmt.Errorf("%w: input %d", ErrUnlockBlockAddressMismatch, i)
+		}
+
+		if _, has := verified[sigUnlockBlock]; has {
+			continue
+		}
+		if _, err := edSig.Valid(essenceHash[:]); err != nil {
+			return fmt.Errorf("unable to verify the unlock block of input %d: %w", i, err)
+		}
+		verified[sigUnlockBlock] = struct{}{}
+	}
+	return nil
+}
+
+func (s *SignedTransactionPayload) Validate() error {
+
+	return nil
+}
+
+// SerializeTransactionBatch serializes the given signed transaction payloads into a batch
+// consisting of a uint32 count followed by the transactions, each prefixed by its uint32 length denotation.
+func SerializeTransactionBatch(txs []*SignedTransactionPayload, deSeriMode DeSerializationMode) ([]byte, error) {
+	s := NewSerializer().WriteNum(uint32(len(txs)), "transaction batch count")
+	for i, tx := range txs {
+		txData, err := tx.Serialize(deSeriMode)
+		if err != nil {
+			return nil, fmt.Errorf("unable to serialize transaction at index %d of batch: %w", i, err)
 		}
-		if _, err := b.Write(unlockBlockSer); err != nil {
+		s.WriteVariableBytes(txData, "transaction")
+	}
+	return s.Serialize()
+}
+
+// ParseTransactionBatch parses a batch of signed transaction payloads previously serialized with SerializeTransactionBatch.
+// Each transaction must consume exactly its length denotation and the batch must not contain any trailing bytes.
+func ParseTransactionBatch(data []byte, deSeriMode DeSerializationMode) ([]*SignedTransactionPayload, error) {
+	var count uint32
+	d := NewDeserializer(data).ReadNum(&count, "transaction batch count")
+	if _, err := d.Done(); err != nil {
+		return nil, err
+	}
+
+	// every transaction occupies at least its length denotation
+	if err := checkMinByteLength(int(count)*UInt32ByteSize, len(data)-UInt32ByteSize); err != nil {
+		return nil, fmt.Errorf("unable to deserialize transaction batch of %d transactions: %w", count, err)
+	}
+
+	txs := make([]*SignedTransactionPayload, 0, count)
+	for i := 0; i < int(count); i++ {
+		var txData []byte
+		tx := &SignedTransactionPayload{}
+		d.ReadVariableBytes(&txData, "transaction").
+			AbortIf(func() error {
+				txBytesRead, err := tx.Deserialize(txData, deSeriMode)
+				if err != nil {
+					return fmt.Errorf("unable to deserialize transaction at index %d of batch: %w", i, err)
+				}
+				if txBytesRead != len(txData) {
+					return fmt.Errorf("%w: transaction at index %d of batch has %d trailing bytes", ErrDeserializationNotAllConsumed, i, len(txData)-txBytesRead)
+				}
+				return nil
+			})
+		if _, err := d.Done(); err != nil {
 			return nil, err
 		}
+		txs = append(txs, tx)
 	}
 
-	return b.Bytes(), nil
+	bytesRead, err := d.Done()
+	if err != nil {
+		return nil, err
+	}
+	if bytesRead != len(data) {
+		return nil, fmt.Errorf("%w: transaction batch has %d trailing bytes", ErrDeserializationNotAllConsumed, len(data)-bytesRead)
+	}
+	return txs, nil
 }
 
-func (s *SignedTransactionPayload) Validate() error {
+// SerializeWithChecksum serializes the given signed transaction payload followed by the
+// little endian IEEE CRC32 checksum of its serialized form.
+func SerializeWithChecksum(tx *SignedTransactionPayload, deSeriMode DeSerializationMode) ([]byte, error) {
+	txData, err := tx.Serialize(deSeriMode)
+	if err != nil {
+		return nil, err
+	}
+	return NewSerializer().
+		WriteBytes(txData, "transaction").
+		WriteNum(crc32.ChecksumIEEE(txData), "transaction checksum").
+		Serialize()
+}
+
+// DeserializeWithChecksum deserializes a signed transaction payload previously serialized with SerializeWithChecksum.
+// An error wrapping ErrChecksumMismatch is returned if the trailing checksum doesn't match the preceding bytes.
+// The checksum must be the last part of data.
+func DeserializeWithChecksum(data []byte, deSeriMode DeSerializationMode) (*SignedTransactionPayload, error) {
+	if err := checkMinByteLength(SignedTransactionPayloadMinSize+TransactionChecksumLength, len(data)); err != nil {
+		return nil, fmt.Errorf("invalid checksummed transaction bytes: %w", err)
+	}
+	txData := data[:len(data)-TransactionChecksumLength]
+	checksum := binary.LittleEndian.Uint32(data[len(txData):])
+	if computed := crc32.ChecksumIEEE(txData); computed != checksum {
+		return nil, fmt.Errorf("%w: checksum is %d but the transaction's is %d", ErrChecksumMismatch, checksum, computed)
+	}
+
+	tx := &SignedTransactionPayload{}
+	txBytesRead, err := tx.Deserialize(txData, deSeriMode)
+	if err != nil {
+		return nil, err
+	}
+	if txBytesRead != len(txData) {
+		return nil, fmt.Errorf("%w: checksummed transaction has %d bytes between the transaction and its checksum", ErrDeserializationNotAllConsumed, len(txData)-txBytesRead)
+	}
+	return tx, nil
+}
 
+type jsonSignedTransactionPayload struct {
+	Type         uint32            `json:"type"`
+	Transaction  json.RawMessage   `json:"transaction"`
+	UnlockBlocks []json.RawMessage `json:"unlock_blocks"`
+}
+
+func (s *SignedTransactionPayload) MarshalJSON() ([]byte, error) {
+	jSigTxPayload := &jsonSignedTransactionPayload{Type: SignedTransactionPayloadID}
+	var err error
+	if jSigTxPayload.Transaction, err = json.Marshal(s.Transaction); err != nil {
+		return nil, err
+	}
+	if jSigTxPayload.UnlockBlocks, err = marshalJSONObjects(s.UnlockBlocks); err != nil {
+		return nil, err
+	}
+	return json.Marshal(jSigTxPayload)
+}
+
+func (s *SignedTransactionPayload) UnmarshalJSON(data []byte) error {
+	jSigTxPayload := &jsonSignedTransactionPayload{}
+	if err := json.Unmarshal(data, jSigTxPayload); err != nil {
+		return err
+	}
+	if err := checkJSONType(jSigTxPayload.Type, SignedTransactionPayloadID, "signed transaction payload"); err != nil {
+		return err
+	}
+	tx, err := unmarshalJSONObject(jSigTxPayload.Transaction, TransactionSelector)
+	if err != nil {
+		return fmt.Errorf("unable to decode transaction from JSON: %w", err)
+	}
+	unlockBlocks, err := unmarshalJSONObjects(jSigTxPayload.UnlockBlocks, UnlockBlockSelector, "unlock block")
+	if err != nil {
+		return err
+	}
+	s.Transaction, s.UnlockBlocks = tx, unlockBlocks
 	return nil
 }
@@ -1,7 +1,10 @@
 package iota_test
 
 import (
+	"crypto/ed25519"
+	"encoding/binary"
 	"errors"
+	"hash/crc32"
 	"testing"
 
 	"github.com/luca-moser/iota"
@@ -33,6 +36,7 @@ func TestSignedTransactionPayload_Deserialize(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, len(tt.source), bytesRead)
 			assert.EqualValues(t, tt.target, tx)
+			AssertTruncationSafe(t, tt.source, func() iota.Serializable { return &iota.SignedTransactionPayload{} })
 		})
 	}
 }
@@ -57,3 +61,393 @@ func TestSignedTransactionPayload_Serialize(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionChecksum(t *testing.T) {
+	tx, txData := randSignedTransactionPayload()
+
+	data, err := iota.SerializeWithChecksum(tx, iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.Equal(t, txData, data[:len(txData)])
+	assert.Equal(t, crc32.ChecksumIEEE(txData), binary.LittleEndian.Uint32(data[len(txData):]))
+
+	parsedTx, err := iota.DeserializeWithChecksum(data, iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.EqualValues(t, tx, parsedTx)
+
+	checksummed := func(b []byte) []byte {
+		var checksum [iota.TransactionChecksumLength]byte
+		binary.LittleEndian.PutUint32(checksum[:], crc32.ChecksumIEEE(b))
+		return append(append([]byte{}, b...), checksum[:]...)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"corrupted transaction", func() []byte {
+			corrupted := append([]byte{}, data...)
+			corrupted[len(txData)/2] ^= 0xff
+			return corrupted
+		}(), iota.ErrChecksumMismatch},
+		{"corrupted checksum", func() []byte {
+			corrupted := append([]byte{}, data...)
+			corrupted[len(corrupted)-1] ^= 0xff
+			return corrupted
+		}(), iota.ErrChecksumMismatch},
+		{"bytes between transaction and checksum", checksummed(append(append([]byte{}, txData...), 0)), iota.ErrDeserializationNotAllConsumed},
+		{"too short", data[:iota.TransactionChecksumLength], iota.ErrDeserializationNotEnoughData},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := iota.DeserializeWithChecksum(tt.data, iota.DeSeriModePerformValidation)
+			assert.True(t, errors.Is(err, tt.err), "expected %v, got %v", tt.err, err)
+		})
+	}
+}
+
+func TestTransactionBatch(t *testing.T) {
+	txs := make([]*iota.SignedTransactionPayload, 0, 3)
+	sizes := map[int]struct{}{}
+	for len(txs) < 3 {
+		tx, txData := randSignedTransactionPayload()
+		if _, has := sizes[len(txData)]; has {
+			continue
+		}
+		sizes[len(txData)] = struct{}{}
+		txs = append(txs, tx)
+	}
+
+	batchData, err := iota.SerializeTransactionBatch(txs, iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+
+	parsedTxs, err := iota.ParseTransactionBatch(batchData, iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.EqualValues(t, txs, parsedTxs)
+
+	t.Run("empty batch", func(t *testing.T) {
+		emptyBatchData, err := iota.SerializeTransactionBatch(nil, iota.DeSeriModePerformValidation)
+		assert.NoError(t, err)
+		parsedTxs, err := iota.ParseTransactionBatch(emptyBatchData, iota.DeSeriModePerformValidation)
+		assert.NoError(t, err)
+		assert.Len(t, parsedTxs, 0)
+	})
+
+	t.Run("trailing bytes", func(t *testing.T) {
+		_, err := iota.ParseTransactionBatch(append(batchData, 0), iota.DeSeriModePerformValidation)
+		assert.True(t, errors.Is(err, iota.ErrDeserializationNotAllConsumed))
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		for i := 0; i < len(batchData); i++ {
+			_, err := iota.ParseTransactionBatch(batchData[:i], iota.DeSeriModePerformValidation)
+			assert.Error(t, err, "no error for batch truncated to %d bytes", i)
+		}
+	})
+}
+
+func TestValidateBytes(t *testing.T) {
+	type test struct {
+		name   string
+		source []byte
+		err    error
+	}
+	tests := []test{
+		func() test {
+			_, sigTxPayData := randSignedTransactionPayload()
+			return test{"ok", sigTxPayData, nil}
+		}(),
+		func() test {
+			unTx, unTxData := randUnsignedTransactionWithIndexationPayload(100)
+			unlockBlocks := iota.Serializables{}
+			for range unTx.Inputs {
+				unlockBlock, _ := randEd25519SignatureUnlockBlock()
+				unlockBlocks = append(unlockBlocks, unlockBlock)
+			}
+			sigTxPayData, err := iota.NewSerializer().
+				WriteNum(iota.SignedTransactionPayloadID, "type").
+				WriteBytes(unTxData, "transaction").
+				WriteSliceOfObjects(unlockBlocks, iota.DeSeriModeNoValidation, nil, "unlock blocks").
+				Serialize()
+			assert.NoError(t, err)
+			return test{"ok with indexation payload", sigTxPayData, nil}
+		}(),
+		func() test {
+			sigTxPay, _ := randSignedTransactionPayload()
+			sigTxPay.UnlockBlocks = append(sigTxPay.UnlockBlocks, sigTxPay.UnlockBlocks[0])
+			sigTxPayData, err := sigTxPay.Serialize(iota.DeSeriModeNoValidation)
+			assert.NoError(t, err)
+			return test{"unlock blocks count mismatch", sigTxPayData, iota.ErrUnlockBlocksMustMatchInputCount}
+		}(),
+		func() test {
+			sigTxPay, _ := randSignedTransactionPayload()
+			unTx := sigTxPay.Transaction.(*iota.UnsignedTransaction)
+			unTx.Inputs = append(iota.Serializables{}, unTx.Inputs[0], unTx.Inputs[0])
+			unTx.Inputs[0] = &iota.UTXOInput{TransactionID: randTxHash(), TransactionOutputIndex: 1}
+			unTx.Inputs[1] = &iota.UTXOInput{TransactionID: [iota.TransactionIDLength]byte{}, TransactionOutputIndex: 1}
+			unlockBlock, _ := randEd25519SignatureUnlockBlock()
+			sigTxPay.UnlockBlocks = iota.Serializables{unlockBlock, &iota.ReferenceUnlockBlock{Reference: 0}}
+			sigTxPayData, err := sigTxPay.Serialize(iota.DeSeriModeNoValidation)
+			assert.NoError(t, err)
+			return test{"inputs not in lexical order", sigTxPayData, iota.ErrInputsOrderViolatesLexicalOrder}
+		}(),
+		func() test {
+			_, sigTxPayData := randSignedTransactionPayload()
+			// turn the first input's type into an unknown one
+			sigTxPayData[iota.TypeDenotationByteSize+iota.TypeDenotationByteSize+iota.UnsignedTransactionFormatVersionByteSize+iota.StructArrayLengthByteSize] = 100
+			return test{"unknown input type", sigTxPayData, iota.ErrUnknownInputType}
+		}(),
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bytesRead, err := iota.ValidateBytes(tt.source, fullValidation, iota.PayloadSelector)
+
+			// must agree with the full deserialization
+			_, deSeriErr := (&iota.SignedTransactionPayload{}).Deserialize(tt.source, fullValidation)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "unexpected error %v", err)
+				assert.True(t, errors.Is(deSeriErr, tt.err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.NoError(t, deSeriErr)
+			assert.Equal(t, len(tt.source), bytesRead)
+
+			for i := 0; i < len(tt.source); i++ {
+				for _, mode := range []iota.DeSerializationMode{iota.DeSeriModeNoValidation, fullValidation} {
+					assert.NotPanics(t, func() {
+						_, err := iota.ValidateBytes(tt.source[:i], mode, iota.PayloadSelector)
+						assert.Error(t, err, "no error for data truncated to %d bytes", i)
+					})
+				}
+			}
+		})
+	}
+}
+
+func TestSignedTransactionPayload_UnlockBlocksValidation(t *testing.T) {
+	twoInputsSigTxPayload := func(unlockBlocks ...iota.Serializable) *iota.SignedTransactionPayload {
+		sigTxPay := oneInputOutputSignedTransactionPayload()
+		unTx := sigTxPay.Transaction.(*iota.UnsignedTransaction)
+		unTx.Inputs = iota.Serializables{
+			&iota.UTXOInput{TransactionID: [iota.TransactionIDLength]byte{}, TransactionOutputIndex: 0},
+			&iota.UTXOInput{TransactionID: [iota.TransactionIDLength]byte{0xff}, TransactionOutputIndex: 0},
+		}
+		sigTxPay.UnlockBlocks = unlockBlocks
+		return sigTxPay
+	}
+	sigUnlockBlock, _ := randEd25519SignatureUnlockBlock()
+
+	type test struct {
+		name   string
+		source *iota.SignedTransactionPayload
+		err    error
+	}
+	tests := []test{
+		{"ok", twoInputsSigTxPayload(sigUnlockBlock, &iota.ReferenceUnlockBlock{Reference: 0}), nil},
+		{"ref before sig", twoInputsSigTxPayload(&iota.ReferenceUnlockBlock{Reference: 1}, sigUnlockBlock), iota.ErrRefUnlockBlockInvalidRef},
+		{"ref to itself", twoInputsSigTxPayload(sigUnlockBlock, &iota.ReferenceUnlockBlock{Reference: 1}), iota.ErrRefUnlockBlockInvalidRef},
+		{"less unlock blocks than inputs", twoInputsSigTxPayload(sigUnlockBlock), iota.ErrUnlockBlocksMustMatchInputCount},
+		{"more unlock blocks than inputs", twoInputsSigTxPayload(sigUnlockBlock, &iota.ReferenceUnlockBlock{Reference: 0}, &iota.ReferenceUnlockBlock{Reference: 0}), iota.ErrUnlockBlocksMustMatchInputCount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, seriErr := tt.source.Serialize(iota.DeSeriModePerformValidation)
+
+			data, err := tt.source.Serialize(iota.DeSeriModeNoValidation)
+			assert.NoError(t, err)
+
+			_, err = (&iota.SignedTransactionPayload{}).Deserialize(data, iota.DeSeriModePerformValidation)
+			if tt.err != nil {
+				assert.True(t, errors.Is(seriErr, tt.err), "unexpected serialization error %v", seriErr)
+				assert.True(t, errors.Is(err, tt.err), "unexpected deserialization error %v", err)
+				return
+			}
+			assert.NoError(t, seriErr)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSignedTransactionPayload_Resign(t *testing.T) {
+	seedA, seedB := randEd25519Seed(), randEd25519Seed()
+	prvKeyA, prvKeyB := ed25519.NewKeyFromSeed(seedA[:]), ed25519.NewKeyFromSeed(seedB[:])
+	addrA := iota.AddressFromEd25519PubKey(prvKeyA.Public().(ed25519.PublicKey))
+	addrB := iota.AddressFromEd25519PubKey(prvKeyB.Public().(ed25519.PublicKey))
+	keys := map[string]ed25519.PrivateKey{string(addrA[:]): prvKeyA, string(addrB[:]): prvKeyB}
+
+	sigTxPay := oneInputOutputSignedTransactionPayload()
+	unTx := sigTxPay.Transaction.(*iota.UnsignedTransaction)
+	unTx.Inputs = iota.Serializables{
+		&iota.UTXOInput{TransactionID: [iota.TransactionIDLength]byte{1}, TransactionOutputIndex: 0},
+		&iota.UTXOInput{TransactionID: [iota.TransactionIDLength]byte{2}, TransactionOutputIndex: 0},
+		&iota.UTXOInput{TransactionID: [iota.TransactionIDLength]byte{3}, TransactionOutputIndex: 0},
+	}
+	inputAddrs := []iota.Serializable{&addrA, &addrB, &addrA}
+
+	assertSignaturesValid := func(t *testing.T) {
+		essenceHash, err := unTx.EssenceHash()
+		assert.NoError(t, err)
+		assert.Len(t, sigTxPay.UnlockBlocks, len(unTx.Inputs))
+		for i, unlockBlock := range sigTxPay.UnlockBlocks {
+			if refUnlockBlock, isRef := unlockBlock.(*iota.ReferenceUnlockBlock); isRef {
+				unlockBlock = sigTxPay.UnlockBlocks[refUnlockBlock.Reference]
+			}
+			sig := unlockBlock.(*iota.SignatureUnlockBlock).Signature.(*iota.Ed25519Signature)
+			assert.True(t, sig.AddressMatches(inputAddrs[i].(*iota.Ed25519Address)), "unlock block %d signed by wrong key", i)
+			valid, err := sig.Valid(essenceHash[:])
+			assert.NoError(t, err)
+			assert.True(t, valid)
+		}
+		_, err = sigTxPay.Serialize(iota.DeSeriModePerformValidation)
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, sigTxPay.Resign(keys, inputAddrs))
+	assertSignaturesValid(t)
+	assert.IsType(t, &iota.ReferenceUnlockBlock{}, sigTxPay.UnlockBlocks[2])
+
+	// modifying an output invalidates the signatures until the transaction is resigned
+	oldSig := sigTxPay.UnlockBlocks[0].(*iota.SignatureUnlockBlock).Signature.(*iota.Ed25519Signature)
+	unTx.Outputs[0].(*iota.SigLockedSingleOutput).Amount++
+	essenceHash, err := unTx.EssenceHash()
+	assert.NoError(t, err)
+	_, err = oldSig.Valid(essenceHash[:])
+	assert.True(t, errors.Is(err, iota.ErrSignatureInvalid))
+
+	assert.NoError(t, sigTxPay.Resign(keys, inputAddrs))
+	assertSignaturesValid(t)
+
+	t.Run("missing key", func(t *testing.T) {
+		unlockBlocks := sigTxPay.UnlockBlocks
+		err := sigTxPay.Resign(map[string]ed25519.PrivateKey{string(addrA[:]): prvKeyA}, inputAddrs)
+		assert.True(t, errors.Is(err, iota.ErrMissingSigningKey))
+		assert.Equal(t, unlockBlocks, sigTxPay.UnlockBlocks)
+	})
+
+	t.Run("key of other address", func(t *testing.T) {
+		err := sigTxPay.Resign(map[string]ed25519.PrivateKey{string(addrA[:]): prvKeyB, string(addrB[:]): prvKeyB}, inputAddrs)
+		assert.True(t, errors.Is(err, iota.ErrSigningKeyAddressMismatch))
+	})
+
+	t.Run("input addresses count mismatch", func(t *testing.T) {
+		err := sigTxPay.Resign(keys, inputAddrs[:2])
+		assert.True(t, errors.Is(err, iota.ErrInputAddressesMustMatchInputs))
+	})
+}
+
+func TestSignedTransactionPayload_SerializeInto(t *testing.T) {
+	type test struct {
+		name   string
+		source *iota.SignedTransactionPayload
+	}
+	tests := []test{
+		func() test {
+			sigTxPay, _ := randSignedTransactionPayload()
+			return test{"ok", sigTxPay}
+		}(),
+		func() test {
+			sigTxPay := oneInputOutputSignedTransactionPayload()
+			sigTxPay.Transaction.(*iota.UnsignedTransaction).Payload, _ = randIndexationPayload()
+			return test{"ok with indexation payload", sigTxPay}
+		}(),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, mode := range []iota.DeSerializationMode{iota.DeSeriModeNoValidation, iota.DeSeriModePerformValidation} {
+				sigTxPayData, err := tt.source.Serialize(mode)
+				assert.NoError(t, err)
+
+				buf := make([]byte, len(sigTxPayData)+10)
+				bytesWritten, err := tt.source.SerializeInto(buf, mode)
+				assert.NoError(t, err)
+				assert.Equal(t, sigTxPayData, buf[:bytesWritten])
+
+				for i := 0; i < len(sigTxPayData); i++ {
+					_, err := tt.source.SerializeInto(make([]byte, i), mode)
+					assert.True(t, errors.Is(err, iota.ErrSerializationBufferTooSmall), "unexpected error %v for buffer of %d bytes", err, i)
+				}
+			}
+		})
+	}
+
+	t.Run("validation", func(t *testing.T) {
+		sigTxPay, _ := randSignedTransactionPayload()
+		sigTxPay.UnlockBlocks = sigTxPay.UnlockBlocks[1:]
+		_, err := sigTxPay.SerializeInto(make([]byte, 4096), iota.DeSeriModePerformValidation)
+		assert.True(t, errors.Is(err, iota.ErrUnlockBlocksMustMatchInputCount))
+	})
+}
+
+func TestSignedTransactionPayload_SerializeIntoAllocs(t *testing.T) {
+	sigTxPay := oneInputOutputSignedTransactionPayload()
+	buf := make([]byte, 1024)
+	serializeIntoAllocs := testing.AllocsPerRun(100, func() {
+		_, err := sigTxPay.SerializeInto(buf, iota.DeSeriModeNoValidation)
+		assert.NoError(t, err)
+	})
+	serializeAllocs := testing.AllocsPerRun(100, func() {
+		_, err := sigTxPay.Serialize(iota.DeSeriModeNoValidation)
+		assert.NoError(t, err)
+	})
+	// only the type byte checks of the input and output allocate
+	assert.EqualValues(t, 2, serializeIntoAllocs)
+	assert.Less(t, serializeIntoAllocs, serializeAllocs)
+
+	edAddr, _ := randEd25519Addr()
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		_, err := edAddr.SerializeInto(buf, iota.DeSeriModeNoValidation)
+		assert.NoError(t, err)
+	}))
+}
+
+func TestSignedTransactionPayload_VerifySignatures(t *testing.T) {
+	seedA, seedB := randEd25519Seed(), randEd25519Seed()
+	prvKeyA, prvKeyB := ed25519.NewKeyFromSeed(seedA[:]), ed25519.NewKeyFromSeed(seedB[:])
+	addrA := iota.AddressFromEd25519PubKey(prvKeyA.Public().(ed25519.PublicKey))
+	addrB := iota.AddressFromEd25519PubKey(prvKeyB.Public().(ed25519.PublicKey))
+	keys := map[iota.Ed25519Address]ed25519.PrivateKey{addrA: prvKeyA, addrB: prvKeyB}
+
+	sigTxPay := oneInputOutputSignedTransactionPayload()
+	unTx := sigTxPay.Transaction.(*iota.UnsignedTransaction)
+	unTx.Inputs = iota.Serializables{
+		&iota.UTXOInput{TransactionID: [iota.TransactionIDLength]byte{1}, TransactionOutputIndex: 0},
+		&iota.UTXOInput{TransactionID: [iota.TransactionIDLength]byte{2}, TransactionOutputIndex: 0},
+		&iota.UTXOInput{TransactionID: [iota.TransactionIDLength]byte{3}, TransactionOutputIndex: 0},
+	}
+	inputAddrs := []iota.Serializable{&addrA, &addrB, &addrA}
+
+	var err error
+	sigTxPay.UnlockBlocks, err = unTx.Sign(inputAddrs, keys)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, sigTxPay.VerifySignatures(inputAddrs))
+
+	t.Run("tampered signature", func(t *testing.T) {
+		sig := sigTxPay.UnlockBlocks[1].(*iota.SignatureUnlockBlock).Signature.(*iota.Ed25519Signature)
+		sig.Signature[0] ^= 0xFF
+		defer func() { sig.Signature[0] ^= 0xFF }()
+		err := sigTxPay.VerifySignatures(inputAddrs)
+		assert.True(t, errors.Is(err, iota.ErrSignatureInvalid), "unexpected error %v", err)
+	})
+
+	t.Run("tampered transaction", func(t *testing.T) {
+		unTx.Outputs[0].(*iota.SigLockedSingleOutput).Amount++
+		defer func() { unTx.Outputs[0].(*iota.SigLockedSingleOutput).Amount-- }()
+		err := sigTxPay.VerifySignatures(inputAddrs)
+		assert.True(t, errors.Is(err, iota.ErrSignatureInvalid), "unexpected error %v", err)
+	})
+
+	t.Run("reference to other address", func(t *testing.T) {
+		err := sigTxPay.VerifySignatures([]iota.Serializable{&addrA, &addrB, &addrB})
+		assert.True(t, errors.Is(err, iota.ErrUnlockBlockAddressMismatch), "unexpected error %v", err)
+	})
+
+	t.Run("input addresses count mismatch", func(t *testing.T) {
+		err := sigTxPay.VerifySignatures(inputAddrs[:2])
+		assert.True(t, errors.Is(err, iota.ErrInputAddressesMustMatchInputs), "unexpected error %v", err)
+	})
+}
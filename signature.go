@@ -3,7 +3,11 @@ package iota
 import (
 	"crypto/ed25519"
 	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 )
 
 // Defines the type of signature.
@@ -19,6 +23,11 @@ const (
 	Ed25519SignatureSerializedBytesSize = TypeDenotationByteSize + ed25519.PublicKeySize + ed25519.SignatureSize
 )
 
+var (
+	ErrSignatureInvalid          = errors.New("signature is invalid")
+	ErrWOTSSignatureNotSupported = errors.New("WOTS signatures are not supported")
+)
+
 // SignatureSelector implements SerializableSelectorFunc for signature types.
 func SignatureSelector(sigType uint32) (Serializable, error) {
 	var seri Serializable
@@ -33,6 +42,7 @@ func SignatureSelector(sigType uint32) (Serializable, error) {
 	return seri, nil
 }
 
+// WOTSSignature is a placeholder for WOTS signatures, which can neither be deserialized nor serialized yet.
 type WOTSSignature struct{}
 
 func (w *WOTSSignature) Deserialize(data []byte, deSeriMode DeSerializationMode) (int, error) {
@@ -41,11 +51,11 @@ func (w *WOTSSignature) Deserialize(data []byte, deSeriMode DeSerializationMode)
 			return 0, fmt.Errorf("unable to deserialize WOTS signature: %w", err)
 		}
 	}
-	panic("implement me")
+	return 0, ErrWOTSSignatureNotSupported
 }
 
 func (w *WOTSSignature) Serialize(deSeriMode DeSerializationMode) ([]byte, error) {
-	panic("implement me")
+	return nil, ErrWOTSSignatureNotSupported
 }
 
 type Ed25519Signature struct {
@@ -62,17 +72,121 @@ func (e *Ed25519Signature) Deserialize(data []byte, deSeriMode DeSerializationMo
 			return 0, fmt.Errorf("unable to deserialize Ed25519 signature: %w", err)
 		}
 	}
-	// skip type byte
-	data = data[TypeDenotationByteSize:]
-	copy(e.PublicKey[:], data[:ed25519.PublicKeySize])
-	copy(e.Signature[:], data[ed25519.PublicKeySize:])
-	return Ed25519SignatureSerializedBytesSize, nil
+	return NewDeserializer(data).
+		Skip(TypeDenotationByteSize, "Ed25519 signature type").
+		ReadBytes(e.PublicKey[:], "Ed25519 signature public key").
+		ReadBytes(e.Signature[:], "Ed25519 signature").
+		Done()
+}
+
+func (e *Ed25519Signature) ValidateBytes(data []byte, deSeriMode DeSerializationMode) (int, error) {
+	return NewDeserializer(data).
+		Skip(Ed25519SignatureSerializedBytesSize, "Ed25519 signature").
+		Done()
 }
 
 func (e *Ed25519Signature) Serialize(deSeriMode DeSerializationMode) ([]byte, error) {
 	var b [Ed25519SignatureSerializedBytesSize]byte
-	binary.LittleEndian.PutUint32(b[:TypeDenotationByteSize], SignatureEd25519)
-	copy(b[TypeDenotationByteSize:], e.PublicKey[:])
-	copy(b[TypeDenotationByteSize+ed25519.PublicKeySize:], e.Signature[:])
+	if _, err := e.SerializeInto(b[:], deSeriMode); err != nil {
+		return nil, err
+	}
 	return b[:], nil
 }
+
+// WriteTo writes the serialized Ed25519Signature to w without allocating, implementing io.WriterTo.
+func (e *Ed25519Signature) WriteTo(w io.Writer) (int64, error) {
+	var b [Ed25519SignatureSerializedBytesSize]byte
+	return writeFixedSizeTo(w, e, b[:])
+}
+
+func (e *Ed25519Signature) SerializeInto(buf []byte, deSeriMode DeSerializationMode) (int, error) {
+	if err := checkSerializationBufferSize(Ed25519SignatureSerializedBytesSize, len(buf)); err != nil {
+		return 0, err
+	}
+	binary.LittleEndian.PutUint32(buf[:TypeDenotationByteSize], SignatureEd25519)
+	copy(buf[TypeDenotationByteSize:], e.PublicKey[:])
+	copy(buf[TypeDenotationByteSize+ed25519.PublicKeySize:], e.Signature[:])
+	return Ed25519SignatureSerializedBytesSize, nil
+}
+
+func (e *Ed25519Signature) SerializedSize() int {
+	return Ed25519SignatureSerializedBytesSize
+}
+
+// PublicKeyBytes returns a copy of the public key, so that modifying the returned slice doesn't alter the signature.
+func (e *Ed25519Signature) PublicKeyBytes() []byte {
+	pubKey := make([]byte, ed25519.PublicKeySize)
+	copy(pubKey, e.PublicKey[:])
+	return pubKey
+}
+
+// SignatureBytes returns a copy of the signature, so that modifying the returned slice doesn't alter the signature.
+func (e *Ed25519Signature) SignatureBytes() []byte {
+	sig := make([]byte, ed25519.SignatureSize)
+	copy(sig, e.Signature[:])
+	return sig
+}
+
+// Valid verifies the signature against the given message using the signature's public key.
+// If the signature is invalid, false is returned together with an error wrapping ErrSignatureInvalid.
+func (e *Ed25519Signature) Valid(message []byte) (bool, error) {
+	if err := VerifyEd25519Raw(e.PublicKey[:], message, e.Signature[:]); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// VerifyEd25519Raw verifies the given raw Ed25519 signature against the given message and raw public key.
+// ErrInvalidBytes is returned if the public key or signature have the wrong length and ErrSignatureInvalid
+// if the signature doesn't verify.
+func VerifyEd25519Raw(pubKey []byte, message []byte, sig []byte) error {
+	if err := checkExactByteLength(ed25519.PublicKeySize, len(pubKey)); err != nil {
+		return fmt.Errorf("invalid Ed25519 public key: %w", err)
+	}
+	if err := checkExactByteLength(ed25519.SignatureSize, len(sig)); err != nil {
+		return fmt.Errorf("invalid Ed25519 signature: %w", err)
+	}
+	if !ed25519.Verify(pubKey, message, sig) {
+		return fmt.Errorf("%w: Ed25519 signature doesn't verify for public key %x", ErrSignatureInvalid, pubKey)
+	}
+	return nil
+}
+
+// Equal tells whether the given signature holds the same public key and signature.
+func (e *Ed25519Signature) Equal(other *Ed25519Signature) bool {
+	return other != nil && *e == *other
+}
+
+// AddressMatches tells whether the given address is the BLAKE2b-256 hash of the signature's public key.
+// The comparison is done in constant time.
+func (e *Ed25519Signature) AddressMatches(addr *Ed25519Address) bool {
+	return addr.Matches(e.PublicKey[:])
+}
+
+type jsonEd25519Signature struct {
+	Type      uint32 `json:"type"`
+	PublicKey string `json:"public_key"`
+	Signature string `json:"signature"`
+}
+
+func (e *Ed25519Signature) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonEd25519Signature{
+		Type:      SignatureEd25519,
+		PublicKey: hex.EncodeToString(e.PublicKey[:]),
+		Signature: hex.EncodeToString(e.Signature[:]),
+	})
+}
+
+func (e *Ed25519Signature) UnmarshalJSON(data []byte) error {
+	jSig := &jsonEd25519Signature{}
+	if err := json.Unmarshal(data, jSig); err != nil {
+		return err
+	}
+	if err := checkJSONType(jSig.Type, SignatureEd25519, "Ed25519 signature"); err != nil {
+		return err
+	}
+	if err := decodeHexInto(e.PublicKey[:], jSig.PublicKey, "Ed25519 signature public key"); err != nil {
+		return err
+	}
+	return decodeHexInto(e.Signature[:], jSig.Signature, "Ed25519 signature")
+}
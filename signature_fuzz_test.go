@@ -0,0 +1,15 @@
+//go:build go1.18
+// +build go1.18
+
+package iota_test
+
+import (
+	"testing"
+
+	"github.com/luca-moser/iota"
+)
+
+func FuzzEd25519SignatureDeserialize(f *testing.F) {
+	_, data := randEd25519Signature()
+	fuzzDeserialize(f, func() iota.Serializable { return &iota.Ed25519Signature{} }, data)
+}
@@ -1,6 +1,7 @@
 package iota_test
 
 import (
+	"crypto/ed25519"
 	"errors"
 	"testing"
 
@@ -42,6 +43,7 @@ func TestEd25519Signature_Deserialize(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, len(tt.source), bytesRead)
 			assert.EqualValues(t, tt.target, edSig)
+			AssertTruncationSafe(t, tt.source, func() iota.Serializable { return &iota.Ed25519Signature{} })
 		})
 	}
 }
@@ -66,3 +68,134 @@ func TestEd25519Signature_Serialize(t *testing.T) {
 		})
 	}
 }
+
+func TestEd25519Signature_RoundTrip(t *testing.T) {
+	originEdSig, _ := randEd25519Signature()
+	edSigData, err := originEdSig.Serialize(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+
+	edSig := &iota.Ed25519Signature{}
+	_, err = edSig.Deserialize(edSigData, iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.Equal(t, originEdSig.PublicKey, edSig.PublicKey)
+	assert.Equal(t, originEdSig.Signature, edSig.Signature)
+}
+
+func TestEd25519Signature_DeserializeEmbedded(t *testing.T) {
+	edSig, edSigData := randEd25519Signature()
+	// trailing data of an enclosing structure must not be consumed
+	data := append(append([]byte{}, edSigData...), randBytes(10)...)
+
+	target := &iota.Ed25519Signature{}
+	bytesRead, err := target.Deserialize(data, iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.Equal(t, len(edSigData), bytesRead)
+	assert.Equal(t, iota.Ed25519SignatureSerializedBytesSize, bytesRead)
+	assert.EqualValues(t, edSig, target)
+}
+
+func TestEd25519Signature_Valid(t *testing.T) {
+	pubKey, prvKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	msg := []byte("寿司を作って")
+
+	edSig := &iota.Ed25519Signature{}
+	copy(edSig.PublicKey[:], pubKey)
+	copy(edSig.Signature[:], ed25519.Sign(prvKey, msg))
+
+	valid, err := edSig.Valid(msg)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = edSig.Valid([]byte("other message"))
+	assert.True(t, errors.Is(err, iota.ErrSignatureInvalid))
+	assert.False(t, valid)
+}
+
+func TestEd25519Signature_BytesGetters(t *testing.T) {
+	edSig, _ := randEd25519Signature()
+	orig := *edSig
+
+	pubKey := edSig.PublicKeyBytes()
+	sig := edSig.SignatureBytes()
+	assert.Equal(t, orig.PublicKey[:], pubKey)
+	assert.Equal(t, orig.Signature[:], sig)
+
+	for i := range pubKey {
+		pubKey[i] ^= 0xff
+	}
+	for i := range sig {
+		sig[i] ^= 0xff
+	}
+	assert.Equal(t, orig, *edSig)
+}
+
+func TestEd25519Signature_AddressMatches(t *testing.T) {
+	pubKey, _, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+
+	edSig := &iota.Ed25519Signature{}
+	copy(edSig.PublicKey[:], pubKey)
+
+	addr := iota.AddressFromEd25519PubKey(pubKey)
+	assert.True(t, edSig.AddressMatches(&addr))
+
+	otherAddr, _ := randEd25519Addr()
+	assert.False(t, edSig.AddressMatches(otherAddr))
+}
+
+func TestVerifyEd25519Raw(t *testing.T) {
+	pubKey, prvKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	msg := []byte("message to sign")
+	sig := ed25519.Sign(prvKey, msg)
+
+	type test struct {
+		name   string
+		pubKey []byte
+		msg    []byte
+		sig    []byte
+		err    error
+	}
+	tests := []test{
+		{"ok", pubKey, msg, sig, nil},
+		{"other message", pubKey, []byte("other message"), sig, iota.ErrSignatureInvalid},
+		func() test {
+			tamperedSig := append([]byte{}, sig...)
+			tamperedSig[0] ^= 0xff
+			return test{"tampered signature", pubKey, msg, tamperedSig, iota.ErrSignatureInvalid}
+		}(),
+		{"short public key", pubKey[:ed25519.PublicKeySize-1], msg, sig, iota.ErrInvalidBytes},
+		{"long signature", pubKey, msg, append(append([]byte{}, sig...), 0), iota.ErrInvalidBytes},
+		{"nil signature", pubKey, msg, nil, iota.ErrInvalidBytes},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := iota.VerifyEd25519Raw(tt.pubKey, tt.msg, tt.sig)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "unexpected error %v", err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEd25519Signature_Equal(t *testing.T) {
+	edSig, edSigData := randEd25519Signature()
+	deserialized := &iota.Ed25519Signature{}
+	_, err := deserialized.Deserialize(edSigData, iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.True(t, edSig.Equal(deserialized))
+
+	deserialized.Signature[0]++
+	assert.False(t, edSig.Equal(deserialized))
+	assert.False(t, edSig.Equal(nil))
+}
+
+func TestWOTSSignature_NotSupported(t *testing.T) {
+	_, err := (&iota.WOTSSignature{}).Deserialize([]byte{byte(iota.SignatureWOTS), 0, 0, 0, 0}, iota.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, iota.ErrWOTSSignatureNotSupported))
+	_, err = (&iota.WOTSSignature{}).Serialize(iota.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, iota.ErrWOTSSignatureNotSupported))
+}
@@ -0,0 +1,247 @@
+// Package testutil provides generators for pseudo-random but syntactically valid objects of the iota package,
+// so that users of the library can write their own round-trip tests against real structures.
+//
+// All generators draw from the rand.Source handed to New, therefore the same seed always yields identical fixtures.
+package testutil
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"math/rand"
+	"sort"
+
+	"github.com/luca-moser/iota"
+)
+
+// Fixtures generates pseudo-random fixtures from a given rand.Source.
+// A Fixtures is not safe for concurrent use, as the underlying rand.Source isn't either.
+type Fixtures struct {
+	rng *rand.Rand
+}
+
+// New creates a new Fixtures generator drawing from the given source.
+func New(src rand.Source) *Fixtures {
+	return &Fixtures{rng: rand.New(src)}
+}
+
+// Bytes returns length amount of random bytes.
+func (f *Fixtures) Bytes(length int) []byte {
+	b := make([]byte, length)
+	f.rng.Read(b)
+	return b
+}
+
+// TransactionID returns a random transaction ID.
+func (f *Fixtures) TransactionID() [iota.TransactionIDLength]byte {
+	var txID [iota.TransactionIDLength]byte
+	copy(txID[:], f.Bytes(iota.TransactionIDLength))
+	return txID
+}
+
+// WOTSAddress returns a random WOTS address.
+func (f *Fixtures) WOTSAddress() *iota.WOTSAddress {
+	addr := &iota.WOTSAddress{}
+	copy(addr[:], f.Bytes(iota.WOTSAddressBytesLength))
+	return addr
+}
+
+// Ed25519Address returns a random Ed25519 address.
+func (f *Fixtures) Ed25519Address() *iota.Ed25519Address {
+	addr := &iota.Ed25519Address{}
+	copy(addr[:], f.Bytes(iota.Ed25519AddressBytesLength))
+	return addr
+}
+
+// AliasAddress returns a random alias address which is never the zeroed alias ID.
+func (f *Fixtures) AliasAddress() *iota.AliasAddress {
+	addr := &iota.AliasAddress{}
+	copy(addr[:], f.Bytes(iota.AliasAddressBytesLength))
+	addr[0] |= 1
+	return addr
+}
+
+// NFTAddress returns a random NFT address which is never the zeroed NFT ID.
+func (f *Fixtures) NFTAddress() *iota.NFTAddress {
+	addr := &iota.NFTAddress{}
+	copy(addr[:], f.Bytes(iota.NFTAddressBytesLength))
+	addr[0] |= 1
+	return addr
+}
+
+// Ed25519Signature returns an Ed25519 signature made up of a random public key and signature.
+// The signature does not verify.
+func (f *Fixtures) Ed25519Signature() *iota.Ed25519Signature {
+	sig := &iota.Ed25519Signature{}
+	copy(sig.PublicKey[:], f.Bytes(ed25519.PublicKeySize))
+	copy(sig.Signature[:], f.Bytes(ed25519.SignatureSize))
+	return sig
+}
+
+// SignatureUnlockBlock returns a signature unlock block holding a random Ed25519 signature.
+func (f *Fixtures) SignatureUnlockBlock() *iota.SignatureUnlockBlock {
+	return &iota.SignatureUnlockBlock{Signature: f.Ed25519Signature()}
+}
+
+// ReferenceUnlockBlock returns a reference unlock block referencing a random unlock block index.
+func (f *Fixtures) ReferenceUnlockBlock() *iota.ReferenceUnlockBlock {
+	return &iota.ReferenceUnlockBlock{Reference: uint16(f.rng.Intn(iota.MaxInputsCount))}
+}
+
+// UTXOInput returns a UTXO input referencing a random transaction ID and output index.
+func (f *Fixtures) UTXOInput() *iota.UTXOInput {
+	return &iota.UTXOInput{
+		TransactionID:          f.TransactionID(),
+		TransactionOutputIndex: uint16(f.rng.Intn(iota.RefUTXOIndexMax)),
+	}
+}
+
+// SigLockedSingleOutput returns a sig locked single output to a random Ed25519 address with a non-zero amount.
+func (f *Fixtures) SigLockedSingleOutput() *iota.SigLockedSingleOutput {
+	return &iota.SigLockedSingleOutput{
+		Address: f.Ed25519Address(),
+		Amount:  uint64(f.rng.Intn(10000) + 1),
+	}
+}
+
+// SigLockedDustAllowanceOutput returns a sig locked dust allowance output to a random Ed25519 address
+// which deposits at least DustAllowanceMinimum.
+func (f *Fixtures) SigLockedDustAllowanceOutput() *iota.SigLockedDustAllowanceOutput {
+	return &iota.SigLockedDustAllowanceOutput{
+		Address: f.Ed25519Address(),
+		Amount:  iota.DustAllowanceMinimum + uint64(f.rng.Intn(10000)),
+	}
+}
+
+// TreasuryInput returns a treasury input referencing a random milestone ID.
+func (f *Fixtures) TreasuryInput() *iota.TreasuryInput {
+	input := &iota.TreasuryInput{}
+	copy(input[:], f.Bytes(iota.MilestoneIDLength))
+	return input
+}
+
+// TreasuryOutput returns a treasury output holding a random amount.
+func (f *Fixtures) TreasuryOutput() *iota.TreasuryOutput {
+	return &iota.TreasuryOutput{Amount: uint64(f.rng.Intn(10000))}
+}
+
+// TreasuryTransaction returns a treasury transaction spending a random treasury input to a random treasury output.
+func (f *Fixtures) TreasuryTransaction() *iota.TreasuryTransaction {
+	return &iota.TreasuryTransaction{Input: f.TreasuryInput(), Output: f.TreasuryOutput()}
+}
+
+// MigratedFundsEntry returns a migrated funds entry with a random tail transaction hash
+// depositing a non-zero amount to a random Ed25519 address.
+func (f *Fixtures) MigratedFundsEntry() *iota.MigratedFundsEntry {
+	entry := &iota.MigratedFundsEntry{Address: f.Ed25519Address(), Deposit: uint64(f.rng.Intn(10000) + 1)}
+	copy(entry.TailTransactionHash[:], f.Bytes(iota.LegacyTailTransactionHashLength))
+	return entry
+}
+
+// ReceiptPayload returns a receipt with fundsCount random migrated funds entries in their lexical order
+// and a random treasury transaction.
+func (f *Fixtures) ReceiptPayload(fundsCount int) *iota.ReceiptPayload {
+	receipt := &iota.ReceiptPayload{
+		MigratedAt:  uint32(f.rng.Intn(1000)),
+		Final:       f.rng.Intn(2) == 1,
+		Transaction: f.TreasuryTransaction(),
+	}
+	for i := 0; i < fundsCount; i++ {
+		receipt.Funds = append(receipt.Funds, f.MigratedFundsEntry())
+	}
+	sortLexically(receipt.Funds)
+	return receipt
+}
+
+// MilestonePayload returns a milestone payload with a random index and timestamp, random parents, a random inclusion
+// merkle proof and up to three random signatures. The signatures do not verify.
+func (f *Fixtures) MilestonePayload() *iota.MilestonePayload {
+	msPayload := &iota.MilestonePayload{
+		Index:     uint32(f.rng.Intn(1000)),
+		Timestamp: uint64(f.rng.Uint32()),
+		Parents:   f.Parents(f.rng.Intn(iota.MaxParentsInAMessage) + 1),
+	}
+	copy(msPayload.InclusionMerkleProof[:], f.Bytes(iota.MilestoneInclusionMerkleProofLength))
+	for i := f.rng.Intn(3) + 1; i > 0; i-- {
+		var sig [iota.MilestoneSignatureLength]byte
+		copy(sig[:], f.Bytes(iota.MilestoneSignatureLength))
+		msPayload.Signatures = append(msPayload.Signatures, sig)
+	}
+	return msPayload
+}
+
+// LSTransactionUnspentOutputs returns the local snapshot representation of a random transaction
+// holding outputsCount unspent outputs to random Ed25519 addresses.
+func (f *Fixtures) LSTransactionUnspentOutputs(outputsCount int) *iota.LSTransactionUnspentOutputs {
+	tx := &iota.LSTransactionUnspentOutputs{TransactionHash: f.TransactionID()}
+	for i := 0; i < outputsCount; i++ {
+		tx.UnspentOutputs = append(tx.UnspentOutputs, &iota.LSUnspentOutput{
+			Index:   uint16(i),
+			Address: f.Ed25519Address(),
+			Value:   uint64(f.rng.Intn(1000000) + 1),
+		})
+	}
+	return tx
+}
+
+// IndexationPayload returns an indexation payload with a random index and dataLength amount of random data.
+func (f *Fixtures) IndexationPayload(dataLength int) *iota.IndexationPayload {
+	index := f.Bytes(f.rng.Intn(iota.IndexationPayloadIndexMaxLength) + 1)
+	for i := range index {
+		// restrict the index to printable ASCII
+		index[i] = ' ' + index[i]%('~'-' '+1)
+	}
+	return &iota.IndexationPayload{Index: string(index), Data: f.Bytes(dataLength)}
+}
+
+// UnsignedTransaction returns an unsigned transaction without payload, spending inputsCount random UTXO inputs
+// to outputsCount random sig locked single outputs. Inputs and outputs are in their lexical order.
+func (f *Fixtures) UnsignedTransaction(inputsCount int, outputsCount int) *iota.UnsignedTransaction {
+	tx := &iota.UnsignedTransaction{}
+	for i := 0; i < inputsCount; i++ {
+		tx.Inputs = append(tx.Inputs, f.UTXOInput())
+	}
+	for i := 0; i < outputsCount; i++ {
+		tx.Outputs = append(tx.Outputs, f.SigLockedSingleOutput())
+	}
+	sortLexically(tx.Inputs)
+	sortLexically(tx.Outputs)
+	return tx
+}
+
+// SignedTransactionPayload returns a signed transaction payload for a random unsigned transaction
+// holding one signature unlock block per input. The signatures do not verify.
+func (f *Fixtures) SignedTransactionPayload(inputsCount int, outputsCount int) *iota.SignedTransactionPayload {
+	sigTxPayload := &iota.SignedTransactionPayload{Transaction: f.UnsignedTransaction(inputsCount, outputsCount)}
+	for i := 0; i < inputsCount; i++ {
+		sigTxPayload.UnlockBlocks = append(sigTxPayload.UnlockBlocks, f.SignatureUnlockBlock())
+	}
+	return sigTxPayload
+}
+
+// Parents returns count random parents in their lexical order.
+func (f *Fixtures) Parents(count int) iota.SliceOfArraysOf32Bytes {
+	parents := make(iota.SliceOfArraysOf32Bytes, count)
+	for i := range parents {
+		copy(parents[i][:], f.Bytes(iota.MessageHashLength))
+	}
+	sort.Slice(parents, func(i, j int) bool {
+		return bytes.Compare(parents[i][:], parents[j][:]) < 0
+	})
+	return parents
+}
+
+// Message returns a message with random parents, a random nonce and the given payload, which may be nil.
+func (f *Fixtures) Message(payload iota.Serializable) *iota.Message {
+	return &iota.Message{
+		Parents: f.Parents(f.rng.Intn(iota.MaxParentsInAMessage) + 1),
+		Payload: payload,
+		Nonce:   f.rng.Uint64(),
+	}
+}
+
+// sortLexically sorts the given objects by their serialized form.
+func sortLexically(seris iota.Serializables) {
+	if err := seris.Sort(iota.DeSeriModeNoValidation); err != nil {
+		panic(err)
+	}
+}
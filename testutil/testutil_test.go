@@ -0,0 +1,50 @@
+package testutil_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/luca-moser/iota"
+	"github.com/luca-moser/iota/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func fixtureMessage(seed int64) *iota.Message {
+	f := testutil.New(rand.NewSource(seed))
+	sigTxPayload := f.SignedTransactionPayload(3, 2)
+	sigTxPayload.Transaction.(*iota.UnsignedTransaction).Payload = f.IndexationPayload(20)
+	return f.Message(sigTxPayload)
+}
+
+func TestFixtures_SameSeed(t *testing.T) {
+	assert.EqualValues(t, fixtureMessage(42), fixtureMessage(42))
+	assert.NotEqual(t, fixtureMessage(42), fixtureMessage(43))
+}
+
+func TestFixtures_SameSeedPayloads(t *testing.T) {
+	payloads := func(seed int64) []iota.Serializable {
+		f := testutil.New(rand.NewSource(seed))
+		return []iota.Serializable{f.MilestonePayload(), f.ReceiptPayload(3), f.SigLockedDustAllowanceOutput()}
+	}
+	assert.EqualValues(t, payloads(42), payloads(42))
+	assert.NotEqual(t, payloads(42), payloads(43))
+
+	for _, payload := range payloads(1337) {
+		_, err := payload.Serialize(iota.DeSeriModePerformValidation | iota.DeSeriModePerformLexicalOrdering)
+		assert.NoError(t, err, "%T", payload)
+	}
+}
+
+func TestFixtures_RoundTrip(t *testing.T) {
+	msg := fixtureMessage(1337)
+	data, err := msg.Serialize(iota.DeSeriModePerformValidation)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	msgTarget := &iota.Message{}
+	bytesRead, err := msgTarget.Deserialize(data, iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.Len(t, data, bytesRead)
+	assert.EqualValues(t, msg, msgTarget)
+}
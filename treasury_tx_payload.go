@@ -0,0 +1,114 @@
+package iota
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	TreasuryTransactionPayloadID uint32 = 4
+
+	// type + treasury input + treasury output
+	TreasuryTransactionPayloadSize = TypeDenotationByteSize + TreasuryInputSize + TreasuryOutputSize
+)
+
+// treasuryInputSelector implements SerializableSelectorFunc for the inputs of a treasury transaction.
+func treasuryInputSelector(inputType uint32) (Serializable, error) {
+	if byte(inputType) != InputTreasury {
+		return nil, fmt.Errorf("%w: type %d is not a treasury input", ErrUnknownInputType, inputType)
+	}
+	return &TreasuryInput{}, nil
+}
+
+// treasuryOutputSelector implements SerializableSelectorFunc for the outputs of a treasury transaction.
+func treasuryOutputSelector(outputType uint32) (Serializable, error) {
+	if byte(outputType) != OutputTreasuryOutput {
+		return nil, fmt.Errorf("%w: type %d is not a treasury output", ErrUnknownOutputType, outputType)
+	}
+	return &TreasuryOutput{}, nil
+}
+
+// TreasuryTransaction is a transaction which moves the funds of the treasury from one treasury output to a new one.
+type TreasuryTransaction struct {
+	// The input spending the current treasury output.
+	Input Serializable `json:"input"`
+	// The output holding the remaining funds of the treasury.
+	Output Serializable `json:"output"`
+}
+
+func (t *TreasuryTransaction) Deserialize(data []byte, deSeriMode DeSerializationMode) (int, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if err := checkMinByteLength(TreasuryTransactionPayloadSize, len(data)); err != nil {
+			return 0, err
+		}
+		if err := checkType(data, TreasuryTransactionPayloadID); err != nil {
+			return 0, fmt.Errorf("unable to deserialize treasury transaction: %w", err)
+		}
+	}
+
+	return NewDeserializer(data).
+		Skip(TypeDenotationByteSize, "treasury transaction type").
+		ReadObject(&t.Input, deSeriMode, TypeDenotationByte, treasuryInputSelector, "treasury transaction input").
+		ReadObject(&t.Output, deSeriMode, TypeDenotationByte, treasuryOutputSelector, "treasury transaction output").
+		Done()
+}
+
+func (t *TreasuryTransaction) Serialize(deSeriMode DeSerializationMode) ([]byte, error) {
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		if _, ok := t.Input.(*TreasuryInput); !ok {
+			return nil, fmt.Errorf("%w: treasury transaction input must be a treasury input but is %T", ErrUnknownInputType, t.Input)
+		}
+		if _, ok := t.Output.(*TreasuryOutput); !ok {
+			return nil, fmt.Errorf("%w: treasury transaction output must be a treasury output but is %T", ErrUnknownOutputType, t.Output)
+		}
+	}
+	return NewSerializer().
+		WriteNum(TreasuryTransactionPayloadID, "treasury transaction type").
+		WriteObject(t.Input, deSeriMode, "treasury transaction input").
+		WriteObject(t.Output, deSeriMode, "treasury transaction output").
+		Serialize()
+}
+
+// WriteTo serializes the TreasuryTransaction with WriteToMode and writes it to w, implementing io.WriterTo.
+func (t *TreasuryTransaction) WriteTo(w io.Writer) (int64, error) {
+	return writeSerializableTo(w, t)
+}
+
+type jsonTreasuryTransaction struct {
+	Type   uint32          `json:"type"`
+	Input  json.RawMessage `json:"input"`
+	Output json.RawMessage `json:"output"`
+}
+
+func (t *TreasuryTransaction) MarshalJSON() ([]byte, error) {
+	jTx := &jsonTreasuryTransaction{Type: TreasuryTransactionPayloadID}
+	var err error
+	if jTx.Input, err = json.Marshal(t.Input); err != nil {
+		return nil, err
+	}
+	if jTx.Output, err = json.Marshal(t.Output); err != nil {
+		return nil, err
+	}
+	return json.Marshal(jTx)
+}
+
+func (t *TreasuryTransaction) UnmarshalJSON(data []byte) error {
+	jTx := &jsonTreasuryTransaction{}
+	if err := json.Unmarshal(data, jTx); err != nil {
+		return err
+	}
+	if err := checkJSONType(jTx.Type, TreasuryTransactionPayloadID, "treasury transaction"); err != nil {
+		return err
+	}
+	input, err := unmarshalJSONObject(jTx.Input, treasuryInputSelector)
+	if err != nil {
+		return fmt.Errorf("unable to decode treasury transaction input from JSON: %w", err)
+	}
+	output, err := unmarshalJSONObject(jTx.Output, treasuryOutputSelector)
+	if err != nil {
+		return fmt.Errorf("unable to decode treasury transaction output from JSON: %w", err)
+	}
+	t.Input, t.Output = input, output
+	return nil
+}
@@ -0,0 +1,83 @@
+package iota_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/luca-moser/iota"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreasuryTransaction_Deserialize(t *testing.T) {
+	type test struct {
+		name   string
+		source []byte
+		target iota.Serializable
+		err    error
+	}
+	tests := []test{
+		func() test {
+			treasuryTx, treasuryTxData := randTreasuryTransaction()
+			return test{"ok", treasuryTxData, treasuryTx, nil}
+		}(),
+		func() test {
+			treasuryTx, _ := randTreasuryTransaction()
+			treasuryTx.Input, _ = randUTXOInput()
+			treasuryTxData, err := treasuryTx.Serialize(iota.DeSeriModeNoValidation)
+			assert.NoError(t, err)
+			return test{"UTXO input", treasuryTxData, nil, iota.ErrUnknownInputType}
+		}(),
+		func() test {
+			treasuryTx, _ := randTreasuryTransaction()
+			treasuryTx.Output, _ = randSigLockedSingleOutput(iota.AddressEd25519)
+			treasuryTxData, err := treasuryTx.Serialize(iota.DeSeriModeNoValidation)
+			assert.NoError(t, err)
+			return test{"sig locked single output", treasuryTxData, nil, iota.ErrUnknownOutputType}
+		}(),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			treasuryTx := &iota.TreasuryTransaction{}
+			bytesRead, err := treasuryTx.Deserialize(tt.source, iota.DeSeriModePerformValidation)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "expected %v, got %v", tt.err, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, tt.source, bytesRead)
+			assert.EqualValues(t, tt.target, treasuryTx)
+			AssertTruncationSafe(t, tt.source, func() iota.Serializable { return &iota.TreasuryTransaction{} })
+		})
+	}
+}
+
+func TestTreasuryTransaction_Serialize(t *testing.T) {
+	type test struct {
+		name   string
+		source *iota.TreasuryTransaction
+		target []byte
+		err    error
+	}
+	tests := []test{
+		func() test {
+			treasuryTx, treasuryTxData := randTreasuryTransaction()
+			return test{"ok", treasuryTx, treasuryTxData, nil}
+		}(),
+		func() test {
+			treasuryTx, _ := randTreasuryTransaction()
+			treasuryTx.Input, _ = randUTXOInput()
+			return test{"UTXO input", treasuryTx, nil, iota.ErrUnknownInputType}
+		}(),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.source.Serialize(iota.DeSeriModePerformValidation)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "expected %v, got %v", tt.err, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.target, data)
+		})
+	}
+}
@@ -2,8 +2,10 @@ package iota
 
 import (
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 )
 
 // Defines a type of unlock block.
@@ -22,9 +24,20 @@ const (
 var (
 	ErrSigUnlockBlocksNotUnique = errors.New("signature unlock blocks must be unique")
 	// TODO: might also reference something else in the future than just signature unlock blocks
-	ErrRefUnlockBlockInvalidRef = errors.New("reference unlock block must point to a previous signature unlock block")
+	ErrRefUnlockBlockInvalidRef   = errors.New("reference unlock block must point to a previous signature unlock block")
+	ErrRefUnlockBlockIndexInvalid = errors.New("the referenced unlock block index is invalid")
+	ErrUnlockBlockIndexOutOfRange = errors.New("unlock block index is out of range")
 )
 
+// RefUnlockBlockIndexMax returns the max unlock block index a reference unlock block can reference.
+// As a transaction holds one unlock block per input, it is derived from MaxInputCount every time it is called.
+func RefUnlockBlockIndexMax() uint16 {
+	if MaxInputCount == 0 {
+		return 0
+	}
+	return MaxInputCount - 1
+}
+
 // UnlockBlockSelector implements SerializableSelectorFunc for unlock block types.
 func UnlockBlockSelector(unlockBlockType uint32) (Serializable, error) {
 	var seri Serializable
@@ -39,11 +52,55 @@ func UnlockBlockSelector(unlockBlockType uint32) (Serializable, error) {
 	return seri, nil
 }
 
+// UnlockBlocks is a slice of unlock blocks.
+type UnlockBlocks Serializables
+
+// Signatures returns the signature unlock blocks among the unlock blocks in their order.
+func (u UnlockBlocks) Signatures() []*SignatureUnlockBlock {
+	var sigUnlockBlocks []*SignatureUnlockBlock
+	for _, unlockBlock := range u {
+		if sigUnlockBlock, ok := unlockBlock.(*SignatureUnlockBlock); ok {
+			sigUnlockBlocks = append(sigUnlockBlocks, sigUnlockBlock)
+		}
+	}
+	return sigUnlockBlocks
+}
+
+// SignatureForInput returns the signature unlock block which unlocks the input at the given index. A reference unlock
+// block is resolved to the signature unlock block it references. An error wrapping ErrRefUnlockBlockInvalidRef is returned
+// if the reference points out of range or to anything but a signature unlock block, references aren't followed further.
+func (u UnlockBlocks) SignatureForInput(index int) (*SignatureUnlockBlock, error) {
+	if index < 0 || index >= len(u) {
+		return nil, fmt.Errorf("%w: index %d but there are %d unlock blocks", ErrUnlockBlockIndexOutOfRange, index, len(u))
+	}
+	switch unlockBlock := u[index].(type) {
+	case *SignatureUnlockBlock:
+		return unlockBlock, nil
+	case *ReferenceUnlockBlock:
+		ref := int(unlockBlock.Reference)
+		if ref >= len(u) {
+			return nil, fmt.Errorf("%w: unlock block %d references non existent unlock block %d", ErrRefUnlockBlockInvalidRef, index, ref)
+		}
+		sigUnlockBlock, ok := u[ref].(*SignatureUnlockBlock)
+		if !ok {
+			return nil, fmt.Errorf("%w: unlock block %d references unlock block %d of type %T", ErrRefUnlockBlockInvalidRef, index, ref, u[ref])
+		}
+		return sigUnlockBlock, nil
+	default:
+		return nil, fmt.Errorf("%w: unlock block %d is of type %T", ErrUnknownUnlockBlockType, index, unlockBlock)
+	}
+}
+
 // SignatureUnlockBlock holds a signature which unlocks inputs.
 type SignatureUnlockBlock struct {
 	Signature Serializable `json:"signature"`
 }
 
+// NewSignatureUnlockBlock creates a SignatureUnlockBlock holding the given signature.
+func NewSignatureUnlockBlock(sig Serializable) *SignatureUnlockBlock {
+	return &SignatureUnlockBlock{Signature: sig}
+}
+
 func (s *SignatureUnlockBlock) Deserialize(data []byte, deSeriMode DeSerializationMode) (int, error) {
 	if deSeriMode.HasMode(DeSeriModePerformValidation) {
 		if err := checkMinByteLength(SignatureUnlockBlockMinSize, len(data)); err != nil {
@@ -54,18 +111,17 @@ func (s *SignatureUnlockBlock) Deserialize(data []byte, deSeriMode DeSerializati
 		}
 	}
 
-	// skip type byte
-	bytesReadTotal := SmallTypeDenotationByteSize
-	data = data[SmallTypeDenotationByteSize:]
-
-	sig, sigBytesRead, err := DeserializeObject(data, deSeriMode, TypeDenotationByte, SignatureSelector)
-	if err != nil {
-		return 0, err
-	}
-	bytesReadTotal += sigBytesRead
-	s.Signature = sig
+	return NewDeserializer(data).
+		Skip(SmallTypeDenotationByteSize, "signature unlock block type").
+		ReadObject(&s.Signature, deSeriMode, TypeDenotationByte, SignatureSelector, "signature unlock block signature").
+		Done()
+}
 
-	return bytesReadTotal, nil
+func (s *SignatureUnlockBlock) ValidateBytes(data []byte, deSeriMode DeSerializationMode) (int, error) {
+	return NewDeserializer(data).
+		Skip(SmallTypeDenotationByteSize, "signature unlock block type").
+		SkipObject(deSeriMode, TypeDenotationByte, SignatureSelector, "signature unlock block signature").
+		Done()
 }
 
 func (s *SignatureUnlockBlock) Serialize(deSeriMode DeSerializationMode) ([]byte, error) {
@@ -76,11 +132,45 @@ func (s *SignatureUnlockBlock) Serialize(deSeriMode DeSerializationMode) ([]byte
 	return append([]byte{UnlockBlockSignature}, sigBytes...), nil
 }
 
+// WriteTo serializes the SignatureUnlockBlock with WriteToMode and writes it to w, implementing io.WriterTo.
+func (s *SignatureUnlockBlock) WriteTo(w io.Writer) (int64, error) {
+	return writeSerializableTo(w, s)
+}
+
+func (s *SignatureUnlockBlock) SerializeInto(buf []byte, deSeriMode DeSerializationMode) (int, error) {
+	if err := checkSerializationBufferSize(SmallTypeDenotationByteSize, len(buf)); err != nil {
+		return 0, err
+	}
+	buf[0] = UnlockBlockSignature
+	sigBytesWritten, err := SerializeInto(s.Signature, buf[SmallTypeDenotationByteSize:], deSeriMode)
+	if err != nil {
+		return 0, err
+	}
+	return SmallTypeDenotationByteSize + sigBytesWritten, nil
+}
+
+// SerializedSize returns the length of the serialized unlock block, or 0 if it holds an unknown signature type.
+func (s *SignatureUnlockBlock) SerializedSize() int {
+	if _, ok := s.Signature.(*Ed25519Signature); !ok {
+		return 0
+	}
+	return SmallTypeDenotationByteSize + Ed25519SignatureSerializedBytesSize
+}
+
 // ReferenceUnlockBlock is an unlock block which references a previous unlock block.
 type ReferenceUnlockBlock struct {
 	Reference uint16 `json:"reference"`
 }
 
+// NewReferenceUnlockBlock creates a ReferenceUnlockBlock referencing the unlock block at the given index.
+// An error wrapping ErrRefUnlockBlockIndexInvalid is returned if the index exceeds RefUnlockBlockIndexMax.
+func NewReferenceUnlockBlock(ref uint16) (*ReferenceUnlockBlock, error) {
+	if max := RefUnlockBlockIndexMax(); ref > max {
+		return nil, fmt.Errorf("%w: must be between 0 and %d (inclusive) but is %d", ErrRefUnlockBlockIndexInvalid, max, ref)
+	}
+	return &ReferenceUnlockBlock{Reference: ref}, nil
+}
+
 func (r *ReferenceUnlockBlock) Deserialize(data []byte, deSeriMode DeSerializationMode) (int, error) {
 	if deSeriMode.HasMode(DeSeriModePerformValidation) {
 		if err := checkMinByteLength(ReferenceUnlockBlockSize, len(data)); err != nil {
@@ -90,24 +180,51 @@ func (r *ReferenceUnlockBlock) Deserialize(data []byte, deSeriMode DeSerializati
 			return 0, fmt.Errorf("unable to deserialize reference unlock block: %w", err)
 		}
 	}
-	data = data[SmallTypeDenotationByteSize:]
-	r.Reference = binary.LittleEndian.Uint16(data)
-	return ReferenceUnlockBlockSize, nil
+	return NewDeserializer(data).
+		Skip(SmallTypeDenotationByteSize, "reference unlock block type").
+		ReadNum(&r.Reference, "reference unlock block reference").
+		Done()
+}
+
+func (r *ReferenceUnlockBlock) ValidateBytes(data []byte, deSeriMode DeSerializationMode) (int, error) {
+	return NewDeserializer(data).
+		Skip(ReferenceUnlockBlockSize, "reference unlock block").
+		Done()
 }
 
 func (r *ReferenceUnlockBlock) Serialize(deSeriMode DeSerializationMode) ([]byte, error) {
 	var b [ReferenceUnlockBlockSize]byte
-	b[0] = UnlockBlockReference
-	binary.LittleEndian.PutUint16(b[SmallTypeDenotationByteSize:], r.Reference)
+	if _, err := r.SerializeInto(b[:], deSeriMode); err != nil {
+		return nil, err
+	}
 	return b[:], nil
 }
 
+// WriteTo writes the serialized ReferenceUnlockBlock to w without allocating, implementing io.WriterTo.
+func (r *ReferenceUnlockBlock) WriteTo(w io.Writer) (int64, error) {
+	var b [ReferenceUnlockBlockSize]byte
+	return writeFixedSizeTo(w, r, b[:])
+}
+
+func (r *ReferenceUnlockBlock) SerializeInto(buf []byte, deSeriMode DeSerializationMode) (int, error) {
+	if err := checkSerializationBufferSize(ReferenceUnlockBlockSize, len(buf)); err != nil {
+		return 0, err
+	}
+	buf[0] = UnlockBlockReference
+	binary.LittleEndian.PutUint16(buf[SmallTypeDenotationByteSize:], r.Reference)
+	return ReferenceUnlockBlockSize, nil
+}
+
+func (r *ReferenceUnlockBlock) SerializedSize() int {
+	return ReferenceUnlockBlockSize
+}
+
 // UnlockBlockValidatorFunc which given the index of an unlock block and the unlock block itself, runs validations and returns an error if any should fail.
 type UnlockBlockValidatorFunc func(index int, unlockBlock Serializable) error
 
 // UnlockBlocksSigUniqueAndRefValidator returns a validator which checks that:
 //	1. signature unlock blocks are unique
-//	2. reference unlock blocks reference a previous signature unlock block
+//	2. reference unlock blocks reference a previous signature unlock block directly and not another reference unlock block
 func UnlockBlocksSigUniqueAndRefValidator() UnlockBlockValidatorFunc {
 	seenEdPubKeys := map[string]int{}
 	seenSigBlocks := map[int]struct{}{}
@@ -156,3 +273,53 @@ func ValidateUnlockBlocks(unlockBlocks Serializables, funcs ...UnlockBlockValida
 	}
 	return nil
 }
+
+type jsonSignatureUnlockBlock struct {
+	Type      uint32          `json:"type"`
+	Signature json.RawMessage `json:"signature"`
+}
+
+func (s *SignatureUnlockBlock) MarshalJSON() ([]byte, error) {
+	sigJSON, err := json.Marshal(s.Signature)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&jsonSignatureUnlockBlock{Type: uint32(UnlockBlockSignature), Signature: sigJSON})
+}
+
+func (s *SignatureUnlockBlock) UnmarshalJSON(data []byte) error {
+	jUnlockBlock := &jsonSignatureUnlockBlock{}
+	if err := json.Unmarshal(data, jUnlockBlock); err != nil {
+		return err
+	}
+	if err := checkJSONType(jUnlockBlock.Type, uint32(UnlockBlockSignature), "signature unlock block"); err != nil {
+		return err
+	}
+	sig, err := unmarshalJSONObject(jUnlockBlock.Signature, SignatureSelector)
+	if err != nil {
+		return fmt.Errorf("unable to decode signature unlock block signature from JSON: %w", err)
+	}
+	s.Signature = sig
+	return nil
+}
+
+type jsonReferenceUnlockBlock struct {
+	Type      uint32 `json:"type"`
+	Reference uint16 `json:"reference"`
+}
+
+func (r *ReferenceUnlockBlock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonReferenceUnlockBlock{Type: uint32(UnlockBlockReference), Reference: r.Reference})
+}
+
+func (r *ReferenceUnlockBlock) UnmarshalJSON(data []byte) error {
+	jUnlockBlock := &jsonReferenceUnlockBlock{}
+	if err := json.Unmarshal(data, jUnlockBlock); err != nil {
+		return err
+	}
+	if err := checkJSONType(jUnlockBlock.Type, uint32(UnlockBlockReference), "reference unlock block"); err != nil {
+		return err
+	}
+	r.Reference = jUnlockBlock.Reference
+	return nil
+}
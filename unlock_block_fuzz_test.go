@@ -0,0 +1,20 @@
+//go:build go1.18
+// +build go1.18
+
+package iota_test
+
+import (
+	"testing"
+
+	"github.com/luca-moser/iota"
+)
+
+func FuzzSignatureUnlockBlockDeserialize(f *testing.F) {
+	_, data := randEd25519SignatureUnlockBlock()
+	fuzzDeserialize(f, func() iota.Serializable { return &iota.SignatureUnlockBlock{} }, data)
+}
+
+func FuzzReferenceUnlockBlockDeserialize(f *testing.F) {
+	_, data := randReferenceUnlockBlock()
+	fuzzDeserialize(f, func() iota.Serializable { return &iota.ReferenceUnlockBlock{} }, data)
+}
@@ -42,6 +42,7 @@ func TestSignatureUnlockBlock_Deserialize(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, len(tt.source), bytesRead)
 			assert.EqualValues(t, tt.target, edSig)
+			AssertTruncationSafe(t, tt.source, func() iota.Serializable { return &iota.SignatureUnlockBlock{} })
 		})
 	}
 }
@@ -92,6 +93,7 @@ func TestReferenceUnlockBlock_Deserialize(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, len(tt.source), bytesRead)
 			assert.EqualValues(t, tt.target, edSig)
+			AssertTruncationSafe(t, tt.source, func() iota.Serializable { return &iota.ReferenceUnlockBlock{} })
 		})
 	}
 }
@@ -176,6 +178,21 @@ func TestUnlockBlockValidatorFunc(t *testing.T) {
 				}(),
 			}, funcs: []iota.UnlockBlockValidatorFunc{iota.UnlockBlocksSigUniqueAndRefValidator()}}, true,
 		},
+		{
+			"ref to ref",
+			args{inputs: []iota.Serializable{
+				func() iota.Serializable {
+					block, _ := randEd25519SignatureUnlockBlock()
+					return block
+				}(),
+				func() iota.Serializable {
+					return &iota.ReferenceUnlockBlock{Reference: 0}
+				}(),
+				func() iota.Serializable {
+					return &iota.ReferenceUnlockBlock{Reference: 1}
+				}(),
+			}, funcs: []iota.UnlockBlockValidatorFunc{iota.UnlockBlocksSigUniqueAndRefValidator()}}, true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -185,3 +202,115 @@ func TestUnlockBlockValidatorFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestUnlockBlocksSigUniqueAndRefValidator(t *testing.T) {
+	sigBlock := func() iota.Serializable {
+		block, _ := randEd25519SignatureUnlockBlock()
+		return block
+	}
+
+	type test struct {
+		name         string
+		unlockBlocks iota.Serializables
+		err          error
+	}
+	tests := []test{
+		{"ok", iota.Serializables{sigBlock(), &iota.ReferenceUnlockBlock{Reference: 0}, sigBlock(), &iota.ReferenceUnlockBlock{Reference: 2}}, nil},
+		{"forward reference", iota.Serializables{&iota.ReferenceUnlockBlock{Reference: 1}, sigBlock()}, iota.ErrRefUnlockBlockInvalidRef},
+		{"self reference", iota.Serializables{sigBlock(), &iota.ReferenceUnlockBlock{Reference: 1}}, iota.ErrRefUnlockBlockInvalidRef},
+		{"reference to reference", iota.Serializables{sigBlock(), &iota.ReferenceUnlockBlock{Reference: 0}, &iota.ReferenceUnlockBlock{Reference: 1}}, iota.ErrRefUnlockBlockInvalidRef},
+		func() test {
+			dupBlock := sigBlock()
+			return test{"duplicate signature", iota.Serializables{dupBlock, dupBlock}, iota.ErrSigUnlockBlocksNotUnique}
+		}(),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := iota.ValidateUnlockBlocks(tt.unlockBlocks, iota.UnlockBlocksSigUniqueAndRefValidator())
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "unexpected error %v", err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewSignatureUnlockBlock(t *testing.T) {
+	edSig, _ := randEd25519Signature()
+	sigBlock := iota.NewSignatureUnlockBlock(edSig)
+	assert.Same(t, edSig, sigBlock.Signature)
+}
+
+func TestNewReferenceUnlockBlock(t *testing.T) {
+	type test struct {
+		name string
+		ref  uint16
+		err  error
+	}
+	tests := []test{
+		{"first", 0, nil},
+		{"max", iota.RefUnlockBlockIndexMax(), nil},
+		{"out of bounds", iota.RefUnlockBlockIndexMax() + 1, iota.ErrRefUnlockBlockIndexInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refBlock, err := iota.NewReferenceUnlockBlock(tt.ref)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "unexpected error %v", err)
+				assert.Nil(t, refBlock)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.ref, refBlock.Reference)
+		})
+	}
+}
+
+func TestUnlockBlocks_Signatures(t *testing.T) {
+	sigBlock1, _ := randEd25519SignatureUnlockBlock()
+	sigBlock2, _ := randEd25519SignatureUnlockBlock()
+	unlockBlocks := iota.UnlockBlocks{sigBlock1, &iota.ReferenceUnlockBlock{Reference: 0}, sigBlock2}
+	assert.Equal(t, []*iota.SignatureUnlockBlock{sigBlock1, sigBlock2}, unlockBlocks.Signatures())
+	assert.Empty(t, iota.UnlockBlocks{&iota.ReferenceUnlockBlock{Reference: 0}}.Signatures())
+}
+
+func TestUnlockBlocks_SignatureForInput(t *testing.T) {
+	sigBlock1, _ := randEd25519SignatureUnlockBlock()
+	sigBlock2, _ := randEd25519SignatureUnlockBlock()
+	unlockBlocks := iota.UnlockBlocks{
+		sigBlock1,
+		&iota.ReferenceUnlockBlock{Reference: 0},
+		sigBlock2,
+		&iota.ReferenceUnlockBlock{Reference: 2},
+		&iota.ReferenceUnlockBlock{Reference: 1},
+		&iota.ReferenceUnlockBlock{Reference: 10},
+	}
+	tests := []struct {
+		name   string
+		index  int
+		target *iota.SignatureUnlockBlock
+		err    error
+	}{
+		{"signature unlock block", 0, sigBlock1, nil},
+		{"reference to first signature", 1, sigBlock1, nil},
+		{"second signature unlock block", 2, sigBlock2, nil},
+		{"reference to second signature", 3, sigBlock2, nil},
+		{"reference chain", 4, nil, iota.ErrRefUnlockBlockInvalidRef},
+		{"reference out of range", 5, nil, iota.ErrRefUnlockBlockInvalidRef},
+		{"index out of range", 6, nil, iota.ErrUnlockBlockIndexOutOfRange},
+		{"negative index", -1, nil, iota.ErrUnlockBlockIndexOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sigBlock, err := unlockBlocks.SignatureForInput(tt.index)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "expected %v, got %v", tt.err, err)
+				assert.Nil(t, sigBlock)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Same(t, tt.target, sigBlock)
+		})
+	}
+}
@@ -2,9 +2,17 @@ package iota
 
 import (
 	"bytes"
+	"crypto/ed25519"
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+
+	"golang.org/x/crypto/blake2b"
 )
 
 // Defines the type of transaction.
@@ -14,9 +22,15 @@ const (
 	// Denotes an unsigned transaction.
 	TransactionUnsigned TransactionType = iota
 
+	// The current format version of unsigned transactions, written right after the transaction type denotation.
+	// Deserialize dispatches on it.
+	UnsignedTransactionFormatVersion byte = 1
+
 	TransactionIDLength = 32
+	// The length of the hash of an unsigned transaction which is signed.
+	EssenceHashLength = blake2b.Size256
 
-	UnsignedTransactionMinByteSize = TypeDenotationByteSize + StructArrayLengthByteSize + StructArrayLengthByteSize + PayloadLengthByteSize
+	UnsignedTransactionMinByteSize = TypeDenotationByteSize + UnsignedTransactionFormatVersionByteSize + StructArrayLengthByteSize + StructArrayLengthByteSize + PayloadLengthByteSize
 )
 
 var (
@@ -26,6 +40,17 @@ var (
 	ErrOutputAddrNotUnique               = errors.New("outputs must each deposit to a unique address")
 	ErrOutputsSumExceedsTotalSupply      = errors.New("accumulated output balance exceeds total supply")
 	ErrOutputDepositsMoreThanTotalSupply = errors.New("an output can not deposit more than the total supply")
+	ErrOutputIndexOutOfBounds            = errors.New("output index is out of bounds")
+	ErrMissingUTXO                       = errors.New("input references an output which is not within the UTXO set")
+	ErrInputOutputSumMismatch            = errors.New("the sum of the input amounts doesn't match the sum of the output amounts")
+
+	// UnsignedTransactionMaxPayloadLength defines the max length of a payload embedded within an unsigned transaction.
+	// It is only checked when deserializing with validation. 0 means that the payload length is not bounded.
+	UnsignedTransactionMaxPayloadLength uint32 = 0
+
+	// TransactionIDDomain is the prefix hashed in front of the serialized unsigned transaction to compute its ID.
+	// It separates transaction IDs from message IDs, see MessageIDDomain.
+	TransactionIDDomain = []byte("IOTA-TRANSACTION-ID")
 )
 
 // TransactionSelector implements SerializableSelectorFunc for transaction types.
@@ -46,166 +71,411 @@ type UnsignedTransaction struct {
 	Inputs Serializables `json:"inputs"`
 	// The outputs of this transaction.
 	Outputs Serializables `json:"outputs"`
-	// The optional embedded payload.
+	// The optional embedded payload. A nil Payload means there is no payload and the payload section consists of a zero
+	// payload length only. A present payload always writes its type and fields, even if those are empty.
 	Payload Serializable `json:"payload"`
 }
 
+// NewUnsignedTransaction creates an UnsignedTransaction out of the given inputs, outputs and optional payload.
+// Copies of inputs and outputs are sorted into their lexical order, the given slices are not modified.
+// An error is returned if the resulting transaction isn't syntactically valid, for example
+// one wrapping ErrInputUTXORefsNotUnique if two inputs reference the same UTXO.
+func NewUnsignedTransaction(inputs Serializables, outputs Serializables, payload Serializable) (*UnsignedTransaction, error) {
+	sortedInputs := make(Serializables, len(inputs))
+	copy(sortedInputs, inputs)
+	if err := sortedInputs.Sort(DeSeriModeNoValidation); err != nil {
+		return nil, fmt.Errorf("unable to sort inputs: %w", err)
+	}
+
+	sortedOutputs := make(Serializables, len(outputs))
+	copy(sortedOutputs, outputs)
+	if err := sortedOutputs.Sort(DeSeriModeNoValidation); err != nil {
+		return nil, fmt.Errorf("unable to sort outputs: %w", err)
+	}
+
+	u := &UnsignedTransaction{Inputs: sortedInputs, Outputs: sortedOutputs, Payload: payload}
+	if err := u.SyntacticallyValid(); err != nil {
+		return nil, fmt.Errorf("unable to create unsigned transaction: %w", err)
+	}
+	return u, nil
+}
+
+// Deserialize dispatches on the format version following the transaction type denotation.
+// Unknown format versions return an error wrapping ErrUnsupportedFormatVersion regardless of the mode.
 func (u *UnsignedTransaction) Deserialize(data []byte, deSeriMode DeSerializationMode) (int, error) {
+	if err := checkUnsignedTransactionHeader(data, deSeriMode); err != nil {
+		return 0, fmt.Errorf("unable to deserialize unsigned transaction: %w", err)
+	}
+
+	switch version := data[TypeDenotationByteSize]; version {
+	case UnsignedTransactionFormatVersion:
+		return u.deserializeV1(data, deSeriMode)
+	default:
+		return 0, fmt.Errorf("%w: unsigned transaction format version %d, supported is %d", ErrUnsupportedFormatVersion, version, UnsignedTransactionFormatVersion)
+	}
+}
+
+// checkUnsignedTransactionHeader checks that data holds the transaction type denotation, which must denote
+// TransactionUnsigned if the DeSeriModePerformValidation mode is given, and the format version.
+func checkUnsignedTransactionHeader(data []byte, deSeriMode DeSerializationMode) error {
+	if len(data) < TypeDenotationByteSize+UnsignedTransactionFormatVersionByteSize {
+		return fmt.Errorf("%w: can't read unsigned transaction type and format version", ErrDeserializationNotEnoughData)
+	}
+	if deSeriMode.HasMode(DeSeriModePerformValidation) {
+		return checkType(data, TransactionUnsigned)
+	}
+	return nil
+}
+
+// deserializeV1 deserializes an unsigned transaction in format version 1.
+func (u *UnsignedTransaction) deserializeV1(data []byte, deSeriMode DeSerializationMode) (int, error) {
 	if deSeriMode.HasMode(DeSeriModePerformValidation) {
 		if err := checkMinByteLength(UnsignedTransactionMinByteSize, len(data)); err != nil {
 			return 0, err
 		}
-		if err := checkType(data, TransactionUnsigned); err != nil {
-			return 0, fmt.Errorf("unable to deserialize unsigned transaction: %w", err)
-		}
 	}
 
-	// skip type byte
-	bytesReadTotal := TypeDenotationByteSize
-	data = data[TypeDenotationByteSize:]
-
-	inputs, inputBytesRead, err := DeserializeArrayOfObjects(data, deSeriMode, TypeDenotationByte, InputSelector, &inputsArrayBound)
-	if err != nil {
-		return 0, err
-	}
-	bytesReadTotal += inputBytesRead
+	return NewDeserializer(data).
+		Skip(TypeDenotationByteSize, "unsigned transaction type").
+		Skip(UnsignedTransactionFormatVersionByteSize, "unsigned transaction format version").
+		ReadSliceOfObjects(&u.Inputs, deSeriMode, TypeDenotationByte, InputSelector, inputsArrayRules(), "inputs").
+		AbortIf(func() error {
+			if deSeriMode.HasMode(DeSeriModePerformValidation) {
+				return ValidateInputs(u.Inputs, InputsUTXORefsUniqueValidator())
+			}
+			return nil
+		}).
+		ReadSliceOfObjects(&u.Outputs, deSeriMode, TypeDenotationByte, OutputSelector, outputsArrayRules(), "outputs").
+		AbortIf(func() error {
+			if deSeriMode.HasMode(DeSeriModePerformValidation) {
+				if err := ValidateOutputs(u.Outputs, OutputsAddrUniqueValidator()); err != nil {
+					return err
+				}
+				return ValidateDustAllowanceOutputs(u.Outputs, OutputsDustAllowanceValidator())
+			}
+			return nil
+		}).
+		ReadPayload(&u.Payload, deSeriMode, UnsignedTransactionMaxPayloadLength, "unsigned transaction payload").
+		AbortIf(func() error {
+			// supports only indexation payloads
+			if deSeriMode.HasMode(DeSeriModePerformValidation) && u.Payload != nil {
+				if _, isIndexationPayload := u.Payload.(*IndexationPayload); !isIndexationPayload {
+					return fmt.Errorf("%w: unsigned transactions only allow embedded indexation payloads but got %T instead", ErrInvalidBytes, u.Payload)
+				}
+			}
+			return nil
+		}).
+		Done()
+}
 
+func (u *UnsignedTransaction) ValidateBytes(data []byte, deSeriMode DeSerializationMode) (int, error) {
 	if deSeriMode.HasMode(DeSeriModePerformValidation) {
-		if err := ValidateInputs(inputs, InputsUTXORefsUniqueValidator()); err != nil {
+		if err := checkMinByteLength(UnsignedTransactionMinByteSize, len(data)); err != nil {
 			return 0, err
 		}
 	}
-	u.Inputs = inputs
+	if err := checkUnsignedTransactionHeader(data, deSeriMode); err != nil {
+		return 0, fmt.Errorf("invalid unsigned transaction bytes: %w", err)
+	}
+	if version := data[TypeDenotationByteSize]; version != UnsignedTransactionFormatVersion {
+		return 0, fmt.Errorf("%w: unsigned transaction format version %d, supported is %d", ErrUnsupportedFormatVersion, version, UnsignedTransactionFormatVersion)
+	}
 
-	// advance to outputs
-	data = data[inputBytesRead:]
-	outputs, outputBytesRead, err := DeserializeArrayOfObjects(data, deSeriMode, TypeDenotationByte, OutputSelector, &outputsArrayBound)
-	if err != nil {
+	d := NewDeserializer(data).
+		Skip(TypeDenotationByteSize, "unsigned transaction type").
+		Skip(UnsignedTransactionFormatVersionByteSize, "unsigned transaction format version").
+		SkipSliceOfObjects(deSeriMode, TypeDenotationByte, InputSelector, inputsArrayRules(), "inputs").
+		SkipSliceOfObjects(deSeriMode, TypeDenotationByte, OutputSelector, outputsArrayRules(), "outputs")
+
+	payloadOffset := d.offset
+	return d.
+		SkipPayload(deSeriMode, UnsignedTransactionMaxPayloadLength, "unsigned transaction payload").
+		AbortIf(func() error {
+			// supports only indexation payloads
+			if !deSeriMode.HasMode(DeSeriModePerformValidation) || binary.LittleEndian.Uint32(data[payloadOffset:]) == 0 {
+				return nil
+			}
+			if payloadType := binary.LittleEndian.Uint32(data[payloadOffset+PayloadLengthByteSize:]); payloadType != IndexationPayloadID {
+				return fmt.Errorf("%w: unsigned transactions only allow embedded indexation payloads but got payload type %d instead", ErrInvalidBytes, payloadType)
+			}
+			return nil
+		}).
+		Done()
+}
+
+func (u *UnsignedTransaction) Serialize(deSeriMode DeSerializationMode) (data []byte, err error) {
+	return u.serializeWithoutPayload(deSeriMode).
+		WritePayload(u.Payload, deSeriMode, "unsigned transaction payload").
+		Serialize()
+}
+
+// WriteTo serializes the UnsignedTransaction with WriteToMode and writes it to w, implementing io.WriterTo.
+func (u *UnsignedTransaction) WriteTo(w io.Writer) (int64, error) {
+	return writeSerializableTo(w, u)
+}
+
+// SerializeInto writes the serialized form of the unsigned transaction into buf and returns the amount of bytes written.
+// It performs the same validations as Serialize. If the inputs, outputs and payload implement BufferSerializable and
+// no validation is performed, the only allocations left are the ones of the selectors checking the type bytes.
+func (u *UnsignedTransaction) SerializeInto(buf []byte, deSeriMode DeSerializationMode) (int, error) {
+	if err := u.validateInputsAndOutputs(deSeriMode); err != nil {
 		return 0, err
 	}
-	bytesReadTotal += outputBytesRead
+	inputsLexicalOrderValidator := inputsArrayRules().lexicalOrderValidatorFor(deSeriMode)
+	outputsLexicalOrderValidator := outputsArrayRules().lexicalOrderValidatorFor(deSeriMode)
 
-	if deSeriMode.HasMode(DeSeriModePerformValidation) {
-		if err := ValidateOutputs(outputs, OutputsAddrUniqueValidator()); err != nil {
-			return 0, err
-		}
+	if err := checkSerializationBufferSize(TypeDenotationByteSize+UnsignedTransactionFormatVersionByteSize, len(buf)); err != nil {
+		return 0, err
 	}
-	u.Outputs = outputs
+	binary.LittleEndian.PutUint32(buf, TransactionUnsigned)
+	buf[TypeDenotationByteSize] = UnsignedTransactionFormatVersion
+	offset := TypeDenotationByteSize + UnsignedTransactionFormatVersionByteSize
 
-	// advance to payload
-	data = data[outputBytesRead:]
+	inputsBytesWritten, err := serializeSliceOfObjectsInto(u.Inputs, buf[offset:], deSeriMode, InputSelector, inputsLexicalOrderValidator, "input")
+	if err != nil {
+		return 0, err
+	}
+	offset += inputsBytesWritten
 
-	payload, payloadBytesRead, err := ParsePayload(data, deSeriMode)
+	outputsBytesWritten, err := serializeSliceOfObjectsInto(u.Outputs, buf[offset:], deSeriMode, OutputSelector, outputsLexicalOrderValidator, "output")
 	if err != nil {
-		return 0, fmt.Errorf("%w: can't parse payload within unsigned transaction", err)
+		return 0, err
 	}
-	bytesReadTotal += payloadBytesRead
+	offset += outputsBytesWritten
 
-	if deSeriMode.HasMode(DeSeriModePerformValidation) {
-		if payload != nil {
-			// supports only indexation payloads
-			if _, isIndexationPayload := payload.(*IndexationPayload); !isIndexationPayload {
-				return 0, fmt.Errorf("%w: unsigned transactions only allow embedded indexation payloads but got %T instead", ErrInvalidBytes, payload)
-			}
+	if err := checkSerializationBufferSize(offset+PayloadLengthByteSize, len(buf)); err != nil {
+		return 0, err
+	}
+	payloadLengthOffset := offset
+	offset += PayloadLengthByteSize
+	var payloadBytesWritten int
+	if u.Payload != nil {
+		if payloadBytesWritten, err = SerializeInto(u.Payload, buf[offset:], deSeriMode); err != nil {
+			return 0, fmt.Errorf("unable to serialize unsigned transaction payload: %w", err)
 		}
 	}
+	binary.LittleEndian.PutUint32(buf[payloadLengthOffset:], uint32(payloadBytesWritten))
 
-	u.Payload = payload
-
-	return bytesReadTotal, nil
+	return offset + payloadBytesWritten, nil
 }
 
-func (u *UnsignedTransaction) Serialize(deSeriMode DeSerializationMode) (data []byte, err error) {
-	if deSeriMode.HasMode(DeSeriModePerformValidation) {
-		if err := ValidateInputs(u.Inputs, InputsUTXORefsUniqueValidator()); err != nil {
-			return nil, err
+// SerializedSize returns the length of the serialized form of the unsigned transaction. Inputs, outputs and the payload
+// which implement SizeHinter aren't serialized, the others are serialized without validation to determine their length.
+func (u *UnsignedTransaction) SerializedSize() (int, error) {
+	size := TypeDenotationByteSize + UnsignedTransactionFormatVersionByteSize + StructArrayLengthByteSize + StructArrayLengthByteSize + PayloadLengthByteSize
+	for _, seris := range []Serializables{u.Inputs, u.Outputs} {
+		for _, seri := range seris {
+			seriSize, err := serializedSizeOf(seri)
+			if err != nil {
+				return 0, err
+			}
+			size += seriSize
 		}
-		if err := ValidateOutputs(u.Outputs, OutputsAddrUniqueValidator()); err != nil {
-			return nil, err
+	}
+	if u.Payload != nil {
+		payloadSize, err := serializedSizeOf(u.Payload)
+		if err != nil {
+			return 0, fmt.Errorf("unable to determine the size of the unsigned transaction payload: %w", err)
 		}
+		size += payloadSize
 	}
+	return size, nil
+}
 
-	var buf bytes.Buffer
-	if err := binary.Write(&buf, binary.LittleEndian, TransactionUnsigned); err != nil {
-		return nil, err
+// ID computes the ID of the unsigned transaction, which is the BLAKE2b-256 hash of TransactionIDDomain followed by its serialized form.
+// The transaction is always serialized without validation, so the ID does not depend on any DeSerializationMode.
+func (u *UnsignedTransaction) ID() ([TransactionIDLength]byte, error) {
+	return u.IDWithHasher(DefaultHasher())
+}
+
+// IDWithHasher computes the ID of the unsigned transaction like ID but hashes with the given Hasher.
+func (u *UnsignedTransaction) IDWithHasher(hasher Hasher) ([TransactionIDLength]byte, error) {
+	data, err := u.Serialize(DeSeriModeNoValidation)
+	if err != nil {
+		return [TransactionIDLength]byte{}, fmt.Errorf("unable to compute unsigned transaction ID: %w", err)
 	}
+	return hasher.Hash(TransactionIDDomain, data), nil
+}
+
+// EssenceHash computes the BLAKE2b-256 hash of the serialized unsigned transaction, which is the message
+// the Ed25519 signatures within the unlock blocks of a signed transaction payload sign.
+// The transaction is serialized with validation and lexical ordering, as an invalid transaction must not be signed.
+func (u *UnsignedTransaction) EssenceHash() ([EssenceHashLength]byte, error) {
+	data, err := u.Serialize(DeSeriModePerformValidation | DeSeriModePerformLexicalOrdering)
+	if err != nil {
+		return [EssenceHashLength]byte{}, fmt.Errorf("unable to compute unsigned transaction essence hash: %w", err)
+	}
+	return blake2b.Sum256(data), nil
+}
+
+// Sign signs the essence hash of the unsigned transaction and returns the unlock blocks unlocking its inputs.
+// inputAddresses must hold the address of the output referenced by the input at the same index and keys the
+// private key of every such address. The first input of every address gets a signature unlock block and
+// subsequent inputs of the same address reference it. An error wrapping ErrMissingSigningKey is returned
+// if the key of an input's address is missing.
+func (u *UnsignedTransaction) Sign(inputAddresses []Serializable, keys map[Ed25519Address]ed25519.PrivateKey) (Serializables, error) {
+	return u.sign(inputAddresses, func(addr *Ed25519Address) (ed25519.PrivateKey, bool) {
+		prvKey, has := keys[*addr]
+		return prvKey, has
+	})
+}
 
-	var inputsLexicalOrderValidator LexicalOrderFunc
-	if deSeriMode.HasMode(DeSeriModePerformValidation) && inputsArrayBound.ElementBytesLexicalOrder {
-		inputsLexicalOrderValidator = inputsArrayBound.LexicalOrderValidator()
+// sign produces the unlock blocks for the inputs of the unsigned transaction with the private keys returned by keyFor.
+func (u *UnsignedTransaction) sign(inputAddresses []Serializable, keyFor func(addr *Ed25519Address) (ed25519.PrivateKey, bool)) (Serializables, error) {
+	if len(inputAddresses) != len(u.Inputs) {
+		return nil, fmt.Errorf("%w: %d inputs but %d addresses", ErrInputAddressesMustMatchInputs, len(u.Inputs), len(inputAddresses))
 	}
 
-	// write inputs
-	if err := binary.Write(&buf, binary.LittleEndian, uint16(len(u.Inputs))); err != nil {
+	essenceHash, err := u.EssenceHash()
+	if err != nil {
 		return nil, err
 	}
-	for i := range u.Inputs {
-		inputSer, err := u.Inputs[i].Serialize(deSeriMode)
-		if err != nil {
-			return nil, fmt.Errorf("unable to serialize input at index %d: %w", i, err)
+
+	unlockBlocks := make(Serializables, len(inputAddresses))
+	sigUnlockBlockIndices := map[Ed25519Address]int{}
+	for i, addr := range inputAddresses {
+		edAddr, ok := addr.(*Ed25519Address)
+		if !ok {
+			return nil, fmt.Errorf("%w: can only sign for Ed25519 addresses but input %d has address of type %T", ErrUnknownAddrType, i, addr)
 		}
-		if _, err := buf.Write(inputSer); err != nil {
-			return nil, err
+		if j, has := sigUnlockBlockIndices[*edAddr]; has {
+			unlockBlocks[i] = &ReferenceUnlockBlock{Reference: uint16(j)}
+			continue
 		}
-		if inputsLexicalOrderValidator != nil {
-			if err := inputsLexicalOrderValidator(i, inputSer); err != nil {
-				return nil, err
-			}
+
+		prvKey, has := keyFor(edAddr)
+		if !has {
+			return nil, fmt.Errorf("%w: input %d", ErrMissingSigningKey, i)
+		}
+		if err := checkExactByteLength(ed25519.PrivateKeySize, len(prvKey)); err != nil {
+			return nil, fmt.Errorf("invalid private key for input %d: %w", i, err)
 		}
+		pubKey := prvKey.Public().(ed25519.PublicKey)
+		if AddressFromEd25519PubKey(pubKey) != *edAddr {
+			return nil, fmt.Errorf("%w: input %d", ErrSigningKeyAddressMismatch, i)
+		}
+
+		sig := &Ed25519Signature{}
+		copy(sig.PublicKey[:], pubKey)
+		copy(sig.Signature[:], ed25519.Sign(prvKey, essenceHash[:]))
+		unlockBlocks[i] = NewSignatureUnlockBlock(sig)
+		sigUnlockBlockIndices[*edAddr] = i
 	}
+	return unlockBlocks, nil
+}
 
-	var outputsLexicalOrderValidator LexicalOrderFunc
-	if deSeriMode.HasMode(DeSeriModePerformValidation) && outputsArrayBound.ElementBytesLexicalOrder {
-		outputsLexicalOrderValidator = outputsArrayBound.LexicalOrderValidator()
+// Equal tells whether the given unsigned transaction serializes to the same bytes, meaning it holds the same
+// inputs, outputs and payload in the same order. Transactions which can't be serialized are never equal.
+func (u *UnsignedTransaction) Equal(other *UnsignedTransaction) bool {
+	if other == nil {
+		return false
+	}
+	data, err := u.Serialize(DeSeriModeNoValidation)
+	if err != nil {
+		return false
+	}
+	otherData, err := other.Serialize(DeSeriModeNoValidation)
+	if err != nil {
+		return false
 	}
+	return bytes.Equal(data, otherData)
+}
 
-	// write outputs
-	if err := binary.Write(&buf, binary.LittleEndian, uint16(len(u.Outputs))); err != nil {
-		return nil, err
+// HasPayload tells whether the unsigned transaction embeds a payload.
+// A payload whose fields are empty, like an indexation payload without data, still counts as a payload.
+func (u *UnsignedTransaction) HasPayload() bool {
+	return u.Payload != nil
+}
+
+// SerializeWithoutPayload serializes the unsigned transaction without its payload section, meaning
+// that neither the payload length denotation nor the payload itself are written.
+// The returned bytes are therefore not deserializable via Deserialize.
+func (u *UnsignedTransaction) SerializeWithoutPayload(deSeriMode DeSerializationMode) ([]byte, error) {
+	return u.serializeWithoutPayload(deSeriMode).Serialize()
+}
+
+// serializeWithoutPayload returns a Serializer which has written everything of the unsigned transaction but its payload section.
+func (u *UnsignedTransaction) serializeWithoutPayload(deSeriMode DeSerializationMode) *Serializer {
+	return NewSerializer().
+		AbortIf(func() error { return u.validateInputsAndOutputs(deSeriMode) }).
+		WriteNum(TransactionUnsigned, "unsigned transaction type").
+		WriteNum(UnsignedTransactionFormatVersion, "unsigned transaction format version").
+		WriteSliceOfObjects(u.Inputs, deSeriMode, typeByteValidator(InputSelector, inputsArrayRules().lexicalOrderValidatorFor(deSeriMode)), "inputs").
+		WriteSliceOfObjects(u.Outputs, deSeriMode, typeByteValidator(OutputSelector, outputsArrayRules().lexicalOrderValidatorFor(deSeriMode)), "outputs")
+}
+
+// validateInputsAndOutputs checks the uniqueness of the inputs' UTXO references and of the outputs' addresses
+// if the DeSeriModePerformValidation mode is given.
+func (u *UnsignedTransaction) validateInputsAndOutputs(deSeriMode DeSerializationMode) error {
+	if !deSeriMode.HasMode(DeSeriModePerformValidation) {
+		return nil
 	}
-	for i := range u.Outputs {
-		outputSer, err := u.Outputs[i].Serialize(deSeriMode)
-		if err != nil {
-			return nil, fmt.Errorf("unable to serialize output at index %d: %w", i, err)
-		}
-		if _, err := buf.Write(outputSer); err != nil {
-			return nil, err
-		}
-		if outputsLexicalOrderValidator != nil {
-			if err := outputsLexicalOrderValidator(i, outputSer); err != nil {
-				return nil, err
-			}
-		}
+	if err := ValidateInputs(u.Inputs, InputsUTXORefsUniqueValidator()); err != nil {
+		return err
+	}
+	if err := ValidateOutputs(u.Outputs, OutputsAddrUniqueValidator()); err != nil {
+		return err
 	}
+	return ValidateDustAllowanceOutputs(u.Outputs, OutputsDustAllowanceValidator())
+}
 
-	// no payload
-	if u.Payload == nil {
-		if err := binary.Write(&buf, binary.LittleEndian, uint32(0)); err != nil {
-			return nil, err
-		}
-		return buf.Bytes(), nil
+// SelfConsistent serializes the unsigned transaction, deserializes the result again and checks that all bytes are
+// consumed and that the deserialized transaction holds elements of the same types which serialize to the same bytes.
+// This catches custom Serializables within the transaction whose serialized form doesn't match what they claim to be.
+// An error wrapping ErrNonCanonicalSerialization is returned on any discrepancy.
+func (u *UnsignedTransaction) SelfConsistent(deSeriMode DeSerializationMode) error {
+	data, err := u.Serialize(deSeriMode)
+	if err != nil {
+		return err
 	}
 
-	// write payload
-	payloadSer, err := u.Payload.Serialize(deSeriMode)
-	if _, err := buf.Write(payloadSer); err != nil {
-		return nil, err
+	deSeriTx := &UnsignedTransaction{}
+	bytesRead, err := deSeriTx.Deserialize(data, deSeriMode)
+	if err != nil {
+		return fmt.Errorf("%w: unable to deserialize serialized form: %v", ErrNonCanonicalSerialization, err)
+	}
+	if bytesRead != len(data) {
+		return fmt.Errorf("%w: serialized form is %d bytes long but only %d were consumed", ErrNonCanonicalSerialization, len(data), bytesRead)
 	}
 
-	if err := binary.Write(&buf, binary.LittleEndian, uint32(len(payloadSer))); err != nil {
-		return nil, err
+	if err := sameElementTypes(u.Inputs, deSeriTx.Inputs); err != nil {
+		return fmt.Errorf("%w: inputs %v", ErrNonCanonicalSerialization, err)
 	}
-	if _, err := buf.Write(payloadSer); err != nil {
-		return nil, err
+	if err := sameElementTypes(u.Outputs, deSeriTx.Outputs); err != nil {
+		return fmt.Errorf("%w: outputs %v", ErrNonCanonicalSerialization, err)
+	}
+	if err := sameElementTypes(Serializables{u.Payload}, Serializables{deSeriTx.Payload}); err != nil {
+		return fmt.Errorf("%w: payload %v", ErrNonCanonicalSerialization, err)
 	}
 
-	return buf.Bytes(), nil
+	reData, err := deSeriTx.Serialize(deSeriMode)
+	if err != nil {
+		return fmt.Errorf("%w: unable to serialize deserialized form: %v", ErrNonCanonicalSerialization, err)
+	}
+	if !bytes.Equal(data, reData) {
+		return fmt.Errorf("%w: deserialized form serializes to different bytes", ErrNonCanonicalSerialization)
+	}
+	return nil
+}
+
+// sameElementTypes returns an error if a and b don't hold elements of the same types at the same indices.
+func sameElementTypes(a Serializables, b Serializables) error {
+	if len(a) != len(b) {
+		return fmt.Errorf("count differs: %d vs. %d", len(a), len(b))
+	}
+	for i := range a {
+		if aType, bType := reflect.TypeOf(a[i]), reflect.TypeOf(b[i]); aType != bType {
+			return fmt.Errorf("element %d differs in type: %v vs. %v", i, aType, bType)
+		}
+	}
+	return nil
 }
 
 // SyntacticallyValid checks whether the unsigned transaction is syntactically valid by checking whether:
 //	1. every input references a unique UTXO and has valid UTXO index bounds
 //	2. every output deposits to a unique address and deposits more than zero
-//	3. the accumulated deposit output is not over the total supply
+//	3. the accumulated deposit of all outputs, including dust allowance outputs, is not over the total supply
+//	4. every dust allowance output deposits at least the dust allowance minimum to a unique address
 // The function does not syntactically validate the input or outputs themselves.
 func (u *UnsignedTransaction) SyntacticallyValid() error {
 	if err := ValidateInputs(u.Inputs,
@@ -215,12 +485,209 @@ func (u *UnsignedTransaction) SyntacticallyValid() error {
 		return err
 	}
 
+	outputsAmountValidator, dustAllowanceAmountValidator := OutputsDepositAmountValidators()
 	if err := ValidateOutputs(u.Outputs,
 		OutputsAddrUniqueValidator(),
-		OutputsDepositAmountValidator(),
+		outputsAmountValidator,
 	); err != nil {
 		return err
 	}
 
+	if err := ValidateDustAllowanceOutputs(u.Outputs, OutputsDustAllowanceValidator(), dustAllowanceAmountValidator); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SemanticallyValidate checks the unsigned transaction against the given set of unspent outputs by checking whether:
+//	1. every input is a UTXO input referencing a non-nil output within inputSet
+//	2. the sum of the referenced outputs' amounts equals the sum of the transaction's output amounts
+// An error wrapping ErrMissingUTXO or ErrInputOutputSumMismatch is returned if either is violated.
+// Inputs other than UTXO inputs and outputs other than signature locked single and dust allowance outputs
+// return an error wrapping ErrUnknownInputType respectively ErrUnknownOutputType.
+// It doesn't check the syntactical validity of the transaction, see SyntacticallyValid.
+func (u *UnsignedTransaction) SemanticallyValidate(inputSet map[UTXOInputID]*SigLockedSingleOutput) error {
+	var inputSum, outputSum uint64
+	for i, input := range u.Inputs {
+		utxoInput, ok := input.(*UTXOInput)
+		if !ok {
+			return fmt.Errorf("%w: input %d is of type %T", ErrUnknownInputType, i, input)
+		}
+		utxo, ok := inputSet[utxoInput.ID()]
+		if !ok || utxo == nil {
+			return fmt.Errorf("%w: input %d", ErrMissingUTXO, i)
+		}
+		if utxo.Amount > math.MaxUint64-inputSum {
+			return fmt.Errorf("%w: input amounts overflow at input %d", ErrInputOutputSumMismatch, i)
+		}
+		inputSum += utxo.Amount
+	}
+
+	for i, output := range u.Outputs {
+		var amount uint64
+		switch out := output.(type) {
+		case *SigLockedSingleOutput:
+			amount = out.Amount
+		case *SigLockedDustAllowanceOutput:
+			amount = out.Amount
+		default:
+			return fmt.Errorf("%w: output %d is of type %T", ErrUnknownOutputType, i, output)
+		}
+		if amount > math.MaxUint64-outputSum {
+			return fmt.Errorf("%w: output amounts overflow at output %d", ErrInputOutputSumMismatch, i)
+		}
+		outputSum += amount
+	}
+
+	if inputSum != outputSum {
+		return fmt.Errorf("%w: inputs sum up to %d but outputs to %d", ErrInputOutputSumMismatch, inputSum, outputSum)
+	}
+	return nil
+}
+
+// ReplaceOutput replaces the output at the given index with the given output, sorts the outputs
+// into their lexical order and checks whether the resulting transaction is still syntactically valid.
+// If the resulting transaction is invalid, an error is returned and the transaction is not modified.
+func (u *UnsignedTransaction) ReplaceOutput(index int, output Serializable) error {
+	if index < 0 || index >= len(u.Outputs) {
+		return fmt.Errorf("%w: index %d but transaction only has %d outputs", ErrOutputIndexOutOfBounds, index, len(u.Outputs))
+	}
+
+	outputs := make(Serializables, len(u.Outputs))
+	copy(outputs, u.Outputs)
+	outputs[index] = output
+
+	if err := outputs.Sort(DeSeriModeNoValidation); err != nil {
+		return fmt.Errorf("unable to sort outputs: %w", err)
+	}
+
+	replaced := &UnsignedTransaction{Inputs: u.Inputs, Outputs: outputs, Payload: u.Payload}
+	if err := replaced.SyntacticallyValid(); err != nil {
+		return fmt.Errorf("unable to replace output %d: %w", index, err)
+	}
+
+	u.Outputs = outputs
+	return nil
+}
+
+// CanonicalInputOrder returns the permutation which sorts the inputs into their lexical order, meaning that the
+// i-th element holds the current index of the input which belongs at position i. The transaction is not modified.
+func (u *UnsignedTransaction) CanonicalInputOrder(deSeriMode DeSerializationMode) ([]int, error) {
+	inputsBytes := make(LexicalOrderedByteSlices, len(u.Inputs))
+	for i, input := range u.Inputs {
+		inputBytes, err := input.Serialize(deSeriMode)
+		if err != nil {
+			return nil, fmt.Errorf("unable to serialize input at index %d: %w", i, err)
+		}
+		inputsBytes[i] = inputBytes
+	}
+
+	order := make([]int, len(u.Inputs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return inputsBytes.Less(order[i], order[j])
+	})
+	return order, nil
+}
+
+// OutputSetDiff compares the outputs of the given transactions by their serialized bytes and
+// returns the outputs which are only in b as added and the outputs which are only in a as removed.
+func OutputSetDiff(a, b *UnsignedTransaction) (added Serializables, removed Serializables, err error) {
+	aKeys, err := serializedKeys(a.Outputs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to serialize outputs of transaction a: %w", err)
+	}
+	bKeys, err := serializedKeys(b.Outputs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to serialize outputs of transaction b: %w", err)
+	}
+
+	aCounts := map[string]int{}
+	for _, k := range aKeys {
+		aCounts[k]++
+	}
+	bCounts := map[string]int{}
+	for _, k := range bKeys {
+		bCounts[k]++
+	}
+
+	for i, k := range bKeys {
+		if aCounts[k] > 0 {
+			aCounts[k]--
+			continue
+		}
+		added = append(added, b.Outputs[i])
+	}
+
+	for i, k := range aKeys {
+		if bCounts[k] > 0 {
+			bCounts[k]--
+			continue
+		}
+		removed = append(removed, a.Outputs[i])
+	}
+
+	return added, removed, nil
+}
+
+// serializedKeys returns the serialized bytes of the given Serializables as strings.
+func serializedKeys(seris Serializables) ([]string, error) {
+	keys := make([]string, len(seris))
+	for i, seri := range seris {
+		seriBytes, err := seri.Serialize(DeSeriModeNoValidation)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		keys[i] = string(seriBytes)
+	}
+	return keys, nil
+}
+
+type jsonUnsignedTransaction struct {
+	Type    uint32            `json:"type"`
+	Inputs  []json.RawMessage `json:"inputs"`
+	Outputs []json.RawMessage `json:"outputs"`
+	Payload json.RawMessage   `json:"payload"`
+}
+
+func (u *UnsignedTransaction) MarshalJSON() ([]byte, error) {
+	jTx := &jsonUnsignedTransaction{Type: TransactionUnsigned}
+	var err error
+	if jTx.Inputs, err = marshalJSONObjects(u.Inputs); err != nil {
+		return nil, err
+	}
+	if jTx.Outputs, err = marshalJSONObjects(u.Outputs); err != nil {
+		return nil, err
+	}
+	if jTx.Payload, err = json.Marshal(u.Payload); err != nil {
+		return nil, err
+	}
+	return json.Marshal(jTx)
+}
+
+func (u *UnsignedTransaction) UnmarshalJSON(data []byte) error {
+	jTx := &jsonUnsignedTransaction{}
+	if err := json.Unmarshal(data, jTx); err != nil {
+		return err
+	}
+	if err := checkJSONType(jTx.Type, TransactionUnsigned, "unsigned transaction"); err != nil {
+		return err
+	}
+	inputs, err := unmarshalJSONObjects(jTx.Inputs, InputSelector, "input")
+	if err != nil {
+		return err
+	}
+	outputs, err := unmarshalJSONObjects(jTx.Outputs, OutputSelector, "output")
+	if err != nil {
+		return err
+	}
+	payload, err := unmarshalJSONPayload(jTx.Payload, "unsigned transaction payload")
+	if err != nil {
+		return err
+	}
+	u.Inputs, u.Outputs, u.Payload = inputs, outputs, payload
 	return nil
 }
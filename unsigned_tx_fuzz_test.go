@@ -0,0 +1,16 @@
+//go:build go1.18
+// +build go1.18
+
+package iota_test
+
+import (
+	"testing"
+
+	"github.com/luca-moser/iota"
+)
+
+func FuzzUnsignedTransactionDeserialize(f *testing.F) {
+	_, data := randUnsignedTransaction()
+	_, withPayloadData := randUnsignedTransactionWithIndexationPayload(20)
+	fuzzDeserialize(f, func() iota.Serializable { return &iota.UnsignedTransaction{} }, data, withPayloadData)
+}
@@ -1,11 +1,16 @@
 package iota_test
 
 import (
+	"crypto/ed25519"
+	"encoding/binary"
 	"errors"
+	"math/rand"
+	"sort"
 	"testing"
 
 	"github.com/luca-moser/iota"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
 )
 
 func TestTransactionSelector(t *testing.T) {
@@ -25,6 +30,15 @@ func TestUnsignedTransaction_Deserialize(t *testing.T) {
 			unTx, unTxData := randUnsignedTransaction()
 			return test{"ok", unTxData, unTx, nil}
 		}(),
+		func() test {
+			unTx, unTxData := randUnsignedTransactionWithIndexationPayload(100)
+			return test{"ok with indexation payload", unTxData, unTx, nil}
+		}(),
+		func() test {
+			unTx, unTxData := randUnsignedTransaction()
+			// cut off within the payload length denotation following the outputs
+			return test{"truncated payload length", unTxData[:len(unTxData)-iota.PayloadLengthByteSize+1], unTx, iota.ErrDeserializationNotEnoughData}
+		}(),
 	}
 
 	for _, tt := range tests {
@@ -38,10 +52,18 @@ func TestUnsignedTransaction_Deserialize(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, len(tt.source), bytesRead)
 			assert.EqualValues(t, tt.target, tx)
+			AssertTruncationSafe(t, tt.source, func() iota.Serializable { return &iota.UnsignedTransaction{} })
 		})
 	}
 }
 
+func TestUnsignedTransaction_DeserializeTruncatedPayloadLengthWithoutValidation(t *testing.T) {
+	_, unTxData := randUnsignedTransaction()
+	truncated := unTxData[:len(unTxData)-iota.PayloadLengthByteSize+1]
+	_, err := (&iota.UnsignedTransaction{}).Deserialize(truncated, iota.DeSeriModeNoValidation)
+	assert.True(t, errors.Is(err, iota.ErrDeserializationNotEnoughData))
+}
+
 func TestUnsignedTransaction_Serialize(t *testing.T) {
 	type test struct {
 		name   string
@@ -53,6 +75,10 @@ func TestUnsignedTransaction_Serialize(t *testing.T) {
 			unTx, unTxData := randUnsignedTransaction()
 			return test{"ok", unTx, unTxData}
 		}(),
+		func() test {
+			unTx, unTxData := randUnsignedTransactionWithIndexationPayload(100)
+			return test{"ok with indexation payload", unTx, unTxData}
+		}(),
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -62,3 +88,746 @@ func TestUnsignedTransaction_Serialize(t *testing.T) {
 		})
 	}
 }
+
+func TestUnsignedTransaction_SerializeLexicalOrder(t *testing.T) {
+	input := func(txIDFirstByte byte) *iota.UTXOInput {
+		return &iota.UTXOInput{TransactionID: [iota.TransactionIDLength]byte{txIDFirstByte}, TransactionOutputIndex: 0}
+	}
+	output := func(addrFirstByte byte) *iota.SigLockedSingleOutput {
+		return &iota.SigLockedSingleOutput{Address: &iota.Ed25519Address{addrFirstByte}, Amount: 1337}
+	}
+
+	type test struct {
+		name   string
+		source *iota.UnsignedTransaction
+		err    error
+	}
+	tests := []test{
+		{"ok", &iota.UnsignedTransaction{
+			Inputs:  iota.Serializables{input(1), input(2)},
+			Outputs: iota.Serializables{output(1), output(2)},
+		}, nil},
+		{"unsorted inputs", &iota.UnsignedTransaction{
+			Inputs:  iota.Serializables{input(2), input(1)},
+			Outputs: iota.Serializables{output(1), output(2)},
+		}, iota.ErrInputsOrderViolatesLexicalOrder},
+		{"unsorted outputs", &iota.UnsignedTransaction{
+			Inputs:  iota.Serializables{input(1), input(2)},
+			Outputs: iota.Serializables{output(2), output(1)},
+		}, iota.ErrOutputsOrderViolatesLexicalOrder},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// elements are never reordered, an unsorted transaction can't be serialized with lexical ordering
+			_, err := tt.source.Serialize(fullValidation)
+			_, serializeIntoErr := tt.source.SerializeInto(make([]byte, 1024), fullValidation)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "unexpected error %v", err)
+				assert.True(t, errors.Is(serializeIntoErr, tt.err), "unexpected error %v", serializeIntoErr)
+
+				// but it can be without, which then fails the deserialization with lexical ordering
+				data, err := tt.source.Serialize(iota.DeSeriModeNoValidation)
+				assert.NoError(t, err)
+				_, err = (&iota.UnsignedTransaction{}).Deserialize(data, fullValidation)
+				assert.True(t, errors.Is(err, tt.err), "unexpected error %v", err)
+
+				// the order is only checked if asked for
+				_, err = (&iota.UnsignedTransaction{}).Deserialize(data, iota.DeSeriModePerformValidation)
+				assert.NoError(t, err)
+				_, err = tt.source.Serialize(iota.DeSeriModePerformValidation)
+				assert.NoError(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NoError(t, serializeIntoErr)
+		})
+	}
+}
+
+func TestNewUnsignedTransaction(t *testing.T) {
+	input := func(txIDFirstByte byte) *iota.UTXOInput {
+		return &iota.UTXOInput{TransactionID: [iota.TransactionIDLength]byte{txIDFirstByte}, TransactionOutputIndex: 0}
+	}
+	output := func(addrFirstByte byte) *iota.SigLockedSingleOutput {
+		return &iota.SigLockedSingleOutput{Address: &iota.Ed25519Address{addrFirstByte}, Amount: 1337}
+	}
+
+	inputs := iota.Serializables{input(3), input(1), input(2)}
+	outputs := iota.Serializables{output(2), output(1)}
+	tx, err := iota.NewUnsignedTransaction(inputs, outputs, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, iota.Serializables{input(1), input(2), input(3)}, tx.Inputs)
+	assert.Equal(t, iota.Serializables{output(1), output(2)}, tx.Outputs)
+	// the given slices are left untouched
+	assert.Equal(t, iota.Serializables{input(3), input(1), input(2)}, inputs)
+
+	_, err = tx.Serialize(fullValidation)
+	assert.NoError(t, err)
+
+	_, err = iota.NewUnsignedTransaction(iota.Serializables{input(2), input(1), input(2)}, outputs, nil)
+	assert.True(t, errors.Is(err, iota.ErrInputUTXORefsNotUnique), "unexpected error %v", err)
+
+	_, err = iota.NewUnsignedTransaction(inputs, iota.Serializables{output(1), output(1)}, nil)
+	assert.True(t, errors.Is(err, iota.ErrOutputAddrNotUnique), "unexpected error %v", err)
+}
+
+func TestUnsignedTransaction_DeserializeEmptyArrays(t *testing.T) {
+	input, _ := randUTXOInput()
+	output, _ := randSigLockedSingleOutput(iota.AddressEd25519)
+
+	type test struct {
+		name   string
+		source *iota.UnsignedTransaction
+		err    error
+	}
+	tests := []test{
+		{"no inputs", &iota.UnsignedTransaction{Outputs: iota.Serializables{output}}, iota.ErrMinInputsNotReached},
+		{"no outputs", &iota.UnsignedTransaction{Inputs: iota.Serializables{input}}, iota.ErrMinOutputsNotReached},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.source.Serialize(iota.DeSeriModeNoValidation)
+			assert.NoError(t, err)
+
+			_, err = (&iota.UnsignedTransaction{}).Deserialize(data, iota.DeSeriModePerformValidation)
+			assert.True(t, errors.Is(err, tt.err), "unexpected error %v", err)
+
+			_, err = (&iota.UnsignedTransaction{}).ValidateBytes(data, iota.DeSeriModePerformValidation)
+			assert.True(t, errors.Is(err, tt.err), "unexpected error %v", err)
+		})
+	}
+}
+
+func TestUnsignedTransaction_AdjustedMaxCounts(t *testing.T) {
+	defer func(prevInputs, prevOutputs uint16) {
+		iota.MaxInputCount, iota.MaxOutputCount = prevInputs, prevOutputs
+	}(iota.MaxInputCount, iota.MaxOutputCount)
+
+	tx := &iota.UnsignedTransaction{
+		Inputs: iota.Serializables{
+			&iota.UTXOInput{TransactionID: [iota.TransactionIDLength]byte{1}},
+			&iota.UTXOInput{TransactionID: [iota.TransactionIDLength]byte{2}},
+		},
+		Outputs: iota.Serializables{
+			&iota.SigLockedSingleOutput{Address: &iota.Ed25519Address{1}, Amount: 1337},
+			&iota.SigLockedSingleOutput{Address: &iota.Ed25519Address{2}, Amount: 1337},
+		},
+	}
+	data, err := tx.Serialize(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+
+	iota.MaxInputCount = 1
+	_, err = (&iota.UnsignedTransaction{}).Deserialize(data, iota.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, iota.ErrMaxInputsExceeded), "unexpected error %v", err)
+
+	iota.MaxInputCount, iota.MaxOutputCount = 2, 1
+	_, err = (&iota.UnsignedTransaction{}).Deserialize(data, iota.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, iota.ErrMaxOutputsExceeded), "unexpected error %v", err)
+	assert.Contains(t, err.Error(), "outputs max is 1 but count is 2")
+
+	iota.MaxOutputCount = 2
+	_, err = (&iota.UnsignedTransaction{}).Deserialize(data, iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+
+	// reference unlock blocks can only reference unlock blocks of the lowered amount of inputs
+	iota.MaxInputCount = 2
+	assert.EqualValues(t, 1, iota.RefUnlockBlockIndexMax())
+	_, err = iota.NewReferenceUnlockBlock(1)
+	assert.NoError(t, err)
+	_, err = iota.NewReferenceUnlockBlock(2)
+	assert.True(t, errors.Is(err, iota.ErrRefUnlockBlockIndexInvalid), "unexpected error %v", err)
+	assert.Contains(t, err.Error(), "between 0 and 1")
+}
+
+func TestUnsignedTransaction_MixedTreasuryInputs(t *testing.T) {
+	unTx, _ := randUnsignedTransaction()
+	treasuryInput := &iota.TreasuryInput{}
+	copy(treasuryInput[:], randBytes(iota.MilestoneIDLength))
+	// treasury inputs serialize after UTXO inputs, keeping the lexical order
+	unTx.Inputs = append(unTx.Inputs, treasuryInput)
+
+	_, err := unTx.Serialize(iota.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, iota.ErrMixedTreasuryInputs))
+
+	unTxData, err := unTx.Serialize(iota.DeSeriModeNoValidation)
+	assert.NoError(t, err)
+	_, err = (&iota.UnsignedTransaction{}).Deserialize(unTxData, iota.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, iota.ErrMixedTreasuryInputs))
+
+	// without validation the treasury input resolves through the InputSelector
+	deserialized := &iota.UnsignedTransaction{}
+	_, err = deserialized.Deserialize(unTxData, iota.DeSeriModeNoValidation)
+	assert.NoError(t, err)
+	assert.EqualValues(t, unTx, deserialized)
+}
+
+func TestUnsignedTransaction_DustAllowanceOutputs(t *testing.T) {
+	unTx, _ := randUnsignedTransaction()
+	dep, _ := randSigLockedDustAllowanceOutput(iota.DustAllowanceMinimum)
+	// dust allowance outputs serialize after signature locked single outputs, keeping the lexical order
+	unTx.Outputs = append(unTx.Outputs, dep)
+
+	unTxData, err := unTx.Serialize(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	deserialized := &iota.UnsignedTransaction{}
+	_, err = deserialized.Deserialize(unTxData, iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.EqualValues(t, unTx, deserialized)
+	assert.NoError(t, unTx.SyntacticallyValid())
+
+	unTx.Outputs = append(unTx.Outputs, &iota.SigLockedDustAllowanceOutput{Address: dep.Address, Amount: iota.DustAllowanceMinimum + 1})
+	_, err = unTx.Serialize(iota.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, iota.ErrDustAllowanceAddrNotUnique))
+	assert.True(t, errors.Is(unTx.SyntacticallyValid(), iota.ErrDustAllowanceAddrNotUnique))
+}
+
+func TestUnsignedTransaction_FormatVersion(t *testing.T) {
+	unTx, unTxData := randUnsignedTransactionWithIndexationPayload(20)
+
+	// the current format round trips
+	deserialized := &iota.UnsignedTransaction{}
+	bytesRead, err := deserialized.Deserialize(unTxData, iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.Len(t, unTxData, bytesRead)
+	assert.EqualValues(t, unTx, deserialized)
+	reserialized, err := deserialized.Serialize(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.Equal(t, unTxData, reserialized)
+
+	assert.Equal(t, iota.UnsignedTransactionFormatVersion, unTxData[iota.TypeDenotationByteSize])
+
+	// version 2 is rejected regardless of the mode
+	v2Data := append([]byte{}, unTxData...)
+	v2Data[iota.TypeDenotationByteSize] = 2
+	for _, deSeriMode := range []iota.DeSerializationMode{iota.DeSeriModePerformValidation, iota.DeSeriModeNoValidation} {
+		_, err := (&iota.UnsignedTransaction{}).Deserialize(v2Data, deSeriMode)
+		assert.True(t, errors.Is(err, iota.ErrUnsupportedFormatVersion), "unexpected error %v", err)
+		_, err = (&iota.UnsignedTransaction{}).ValidateBytes(v2Data, deSeriMode)
+		assert.True(t, errors.Is(err, iota.ErrUnsupportedFormatVersion), "unexpected error %v", err)
+	}
+
+	// a wrong transaction type is a type mismatch, not an unsupported format version
+	wrongTypeData := append([]byte{}, unTxData...)
+	binary.LittleEndian.PutUint32(wrongTypeData, iota.TransactionUnsigned+1)
+	_, err = (&iota.UnsignedTransaction{}).Deserialize(wrongTypeData, iota.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, iota.ErrDeserializationTypeMismatch), "unexpected error %v", err)
+	assert.False(t, errors.Is(err, iota.ErrUnsupportedFormatVersion))
+
+	_, err = (&iota.UnsignedTransaction{}).Deserialize(unTxData[:iota.TypeDenotationByteSize], iota.DeSeriModeNoValidation)
+	assert.True(t, errors.Is(err, iota.ErrDeserializationNotEnoughData), "unexpected error %v", err)
+}
+
+func TestUnsignedTransaction_DustAllowanceOutputsTotalSupply(t *testing.T) {
+	input, _ := randUTXOInput()
+	addr1, _ := randEd25519Addr()
+	addr2, _ := randEd25519Addr()
+	unTx := &iota.UnsignedTransaction{
+		Inputs: iota.Serializables{input},
+		Outputs: iota.Serializables{
+			&iota.SigLockedSingleOutput{Address: addr1, Amount: iota.TokenSupply},
+			&iota.SigLockedDustAllowanceOutput{Address: addr2, Amount: iota.TokenSupply},
+		},
+	}
+	err := unTx.SyntacticallyValid()
+	assert.True(t, errors.Is(err, iota.ErrOutputsSumExceedsTotalSupply), "unexpected error %v", err)
+
+	unTx.Outputs[0].(*iota.SigLockedSingleOutput).Amount = iota.TokenSupply - iota.DustAllowanceMinimum
+	unTx.Outputs[1].(*iota.SigLockedDustAllowanceOutput).Amount = iota.DustAllowanceMinimum
+	assert.NoError(t, unTx.SyntacticallyValid())
+}
+
+func TestUnsignedTransaction_SemanticallyValidate(t *testing.T) {
+	input1, _ := randUTXOInput()
+	input2, _ := randUTXOInput()
+	utxo := func(amount uint64) *iota.SigLockedSingleOutput {
+		addr, _ := randEd25519Addr()
+		return &iota.SigLockedSingleOutput{Address: addr, Amount: amount}
+	}
+	tx := &iota.UnsignedTransaction{
+		Inputs:  iota.Serializables{input1, input2},
+		Outputs: iota.Serializables{utxo(1000), utxo(337)},
+	}
+
+	type test struct {
+		name     string
+		inputSet map[iota.UTXOInputID]*iota.SigLockedSingleOutput
+		err      error
+	}
+	tests := []test{
+		{"balanced", map[iota.UTXOInputID]*iota.SigLockedSingleOutput{
+			input1.ID(): utxo(1300),
+			input2.ID(): utxo(37),
+		}, nil},
+		{"unbalanced", map[iota.UTXOInputID]*iota.SigLockedSingleOutput{
+			input1.ID(): utxo(1300),
+			input2.ID(): utxo(38),
+		}, iota.ErrInputOutputSumMismatch},
+		{"missing input", map[iota.UTXOInputID]*iota.SigLockedSingleOutput{
+			input1.ID(): utxo(1337),
+		}, iota.ErrMissingUTXO},
+		{"nil input", map[iota.UTXOInputID]*iota.SigLockedSingleOutput{
+			input1.ID(): utxo(1337),
+			input2.ID(): nil,
+		}, iota.ErrMissingUTXO},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tx.SemanticallyValidate(tt.inputSet)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "unexpected error %v", err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	// a treasury input is an unsupported input kind rather than an unresolved one
+	treasuryInputTx := &iota.UnsignedTransaction{Inputs: iota.Serializables{&iota.TreasuryInput{}}, Outputs: tx.Outputs}
+	err := treasuryInputTx.SemanticallyValidate(map[iota.UTXOInputID]*iota.SigLockedSingleOutput{})
+	assert.True(t, errors.Is(err, iota.ErrUnknownInputType), "unexpected error %v", err)
+	assert.False(t, errors.Is(err, iota.ErrMissingUTXO))
+
+	// treasury outputs are not allowed within unsigned transactions, so they don't balance inputs
+	treasuryOutputTx := &iota.UnsignedTransaction{Inputs: iota.Serializables{input1}, Outputs: iota.Serializables{&iota.TreasuryOutput{Amount: 1337}}}
+	err = treasuryOutputTx.SemanticallyValidate(map[iota.UTXOInputID]*iota.SigLockedSingleOutput{input1.ID(): utxo(1337)})
+	assert.True(t, errors.Is(err, iota.ErrUnknownOutputType), "unexpected error %v", err)
+}
+
+func TestUnsignedTransaction_ReplaceOutput(t *testing.T) {
+	type test struct {
+		name   string
+		tx     *iota.UnsignedTransaction
+		index  int
+		output iota.Serializable
+		err    error
+	}
+	tests := []test{
+		func() test {
+			unTx, _ := randUnsignedTransaction()
+			dep, _ := randSigLockedSingleOutput(iota.AddressEd25519)
+			dep.Amount = 1337
+			return test{"ok", unTx, len(unTx.Outputs) - 1, dep, nil}
+		}(),
+		func() test {
+			unTx, _ := randUnsignedTransaction()
+			dep, _ := randSigLockedSingleOutput(iota.AddressEd25519)
+			dep.Amount = 0
+			return test{"zero deposit", unTx, 0, dep, iota.ErrDepositAmountMustBeGreaterThanZero}
+		}(),
+		func() test {
+			unTx, _ := randUnsignedTransaction()
+			dep, _ := randSigLockedSingleOutput(iota.AddressEd25519)
+			dep.Amount = 1337
+			return test{"index out of bounds", unTx, len(unTx.Outputs), dep, iota.ErrOutputIndexOutOfBounds}
+		}(),
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originOutputs := append(iota.Serializables{}, tt.tx.Outputs...)
+			err := tt.tx.ReplaceOutput(tt.index, tt.output)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+				assert.Equal(t, originOutputs, tt.tx.Outputs)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, tt.tx.Outputs, len(originOutputs))
+			assert.Contains(t, tt.tx.Outputs, tt.output)
+			assert.NoError(t, tt.tx.SyntacticallyValid())
+
+			// serializing with validation checks the lexical order of the outputs
+			_, err = tt.tx.Serialize(iota.DeSeriModePerformValidation)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUnsignedTransaction_ReplaceOutputLexicalOrder(t *testing.T) {
+	output := func(addrFirstByte byte) *iota.SigLockedSingleOutput {
+		return &iota.SigLockedSingleOutput{Address: &iota.Ed25519Address{addrFirstByte}, Amount: 1337}
+	}
+
+	unTx, _ := randUnsignedTransaction()
+	unTx.Outputs = iota.Serializables{output(1), output(3), output(5)}
+
+	assert.NoError(t, unTx.ReplaceOutput(0, output(4)))
+	AssertSameLexicalOrder(t, iota.Serializables{output(3), output(4), output(5)}, unTx.Outputs, iota.DeSeriModePerformValidation)
+
+	assert.NoError(t, unTx.ReplaceOutput(2, output(2)))
+	AssertSameLexicalOrder(t, iota.Serializables{output(2), output(3), output(4)}, unTx.Outputs, iota.DeSeriModePerformValidation)
+}
+
+func TestOutputSetDiff(t *testing.T) {
+	draft, _ := randUnsignedTransaction()
+
+	removedDep := draft.Outputs[0]
+	addedDep, _ := randSigLockedSingleOutput(iota.AddressEd25519)
+
+	final := &iota.UnsignedTransaction{
+		Inputs:  draft.Inputs,
+		Outputs: append(append(iota.Serializables{}, draft.Outputs[1:]...), addedDep),
+	}
+
+	added, removed, err := iota.OutputSetDiff(draft, final)
+	assert.NoError(t, err)
+	assert.Equal(t, iota.Serializables{addedDep}, added)
+	assert.Equal(t, iota.Serializables{removedDep}, removed)
+
+	added, removed, err = iota.OutputSetDiff(draft, draft)
+	assert.NoError(t, err)
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+}
+
+func TestUnsignedTransaction_DeserializeMaxPayloadLength(t *testing.T) {
+	defer func(maxPayloadLength uint32) {
+		iota.UnsignedTransactionMaxPayloadLength = maxPayloadLength
+	}(iota.UnsignedTransactionMaxPayloadLength)
+
+	unTx, unTxData := randUnsignedTransactionWithIndexationPayload(100)
+	payloadData, err := unTx.Payload.Serialize(iota.DeSeriModeNoValidation)
+	assert.NoError(t, err)
+
+	iota.UnsignedTransactionMaxPayloadLength = uint32(len(payloadData))
+	tx := &iota.UnsignedTransaction{}
+	bytesRead, err := tx.Deserialize(unTxData, iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.Equal(t, len(unTxData), bytesRead)
+	assert.EqualValues(t, unTx, tx)
+
+	iota.UnsignedTransactionMaxPayloadLength = uint32(len(payloadData) - 1)
+	_, err = (&iota.UnsignedTransaction{}).Deserialize(unTxData, iota.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, iota.ErrPayloadTooLarge))
+}
+
+func TestUnsignedTransaction_SerializeWithoutPayload(t *testing.T) {
+	unTx, unTxData := randUnsignedTransaction()
+
+	essenceData, err := unTx.SerializeWithoutPayload(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+
+	// the full serialization only additionally contains the zero payload length
+	assert.Equal(t, unTxData[:len(unTxData)-iota.PayloadLengthByteSize], essenceData)
+	assert.Equal(t, make([]byte, iota.PayloadLengthByteSize), unTxData[len(essenceData):])
+}
+
+func TestUnsignedTransaction_ID(t *testing.T) {
+	unTx, unTxData := randUnsignedTransaction()
+
+	id, err := unTx.ID()
+	assert.NoError(t, err)
+	assert.Equal(t, blake2b.Sum256(append(append([]byte{}, iota.TransactionIDDomain...), unTxData...)), id)
+
+	// the ID is stable across serializations
+	sameID, err := unTx.ID()
+	assert.NoError(t, err)
+	assert.Equal(t, id, sameID)
+
+	// a different transaction yields a different ID
+	otherUnTx, _ := randUnsignedTransaction()
+	otherID, err := otherUnTx.ID()
+	assert.NoError(t, err)
+	assert.NotEqual(t, id, otherID)
+
+	// an output which can't be serialized
+	unTx.Outputs = append(unTx.Outputs, &iota.SigLockedSingleOutput{Amount: 1})
+	_, err = unTx.ID()
+	assert.True(t, errors.Is(err, iota.ErrUnknownAddrType))
+}
+
+// stubSerializable serializes to the given bytes.
+type stubSerializable []byte
+
+func (s stubSerializable) Deserialize(data []byte, deSeriMode iota.DeSerializationMode) (int, error) {
+	return 0, nil
+}
+
+func (s stubSerializable) Serialize(deSeriMode iota.DeSerializationMode) ([]byte, error) {
+	return s, nil
+}
+
+func TestUnsignedTransaction_SerializeInvalidElementBytes(t *testing.T) {
+	type test struct {
+		name   string
+		modify func(tx *iota.UnsignedTransaction)
+	}
+	tests := []test{
+		{"empty input", func(tx *iota.UnsignedTransaction) { tx.Inputs[0] = stubSerializable{} }},
+		{"unknown input type", func(tx *iota.UnsignedTransaction) { tx.Inputs[0] = stubSerializable{100} }},
+		{"empty output", func(tx *iota.UnsignedTransaction) { tx.Outputs[0] = stubSerializable{} }},
+		{"unknown output type", func(tx *iota.UnsignedTransaction) { tx.Outputs[0] = stubSerializable{100} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unTx, _ := randUnsignedTransaction()
+			tt.modify(unTx)
+			_, err := unTx.Serialize(iota.DeSeriModeNoValidation)
+			assert.True(t, errors.Is(err, iota.ErrInvalidBytes), "unexpected error %v", err)
+		})
+	}
+}
+
+func TestUnsignedTransaction_RoundTripWithPayload(t *testing.T) {
+	unTx, _ := randUnsignedTransaction()
+	unTx.Payload, _ = randIndexationPayload()
+
+	data, err := unTx.Serialize(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+
+	deserialized := &iota.UnsignedTransaction{}
+	bytesRead, err := deserialized.Deserialize(data, iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.Equal(t, len(data), bytesRead)
+	assert.EqualValues(t, unTx, deserialized)
+
+	// also when embedded within a message
+	sigTxPay, _ := randSignedTransactionPayload()
+	sigTxPay.Transaction = unTx
+	sigTxPay.UnlockBlocks = sigTxPay.UnlockBlocks[:0]
+	for range unTx.Inputs {
+		unlockBlock, _ := randEd25519SignatureUnlockBlock()
+		sigTxPay.UnlockBlocks = append(sigTxPay.UnlockBlocks, unlockBlock)
+	}
+	msg := &iota.Message{Parents: randSortedParents(2), Payload: sigTxPay, Nonce: 1337}
+
+	msgData, err := msg.Serialize(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+
+	deserializedMsg := &iota.Message{}
+	_, err = deserializedMsg.Deserialize(msgData, iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.EqualValues(t, msg, deserializedMsg)
+}
+
+func TestUnsignedTransaction_RoundTripWithoutPayload(t *testing.T) {
+	unTx, unTxData := randUnsignedTransaction()
+
+	// the payload section is a single zero uint32 payload length
+	assert.Equal(t, []byte{0, 0, 0, 0}, unTxData[len(unTxData)-iota.PayloadLengthByteSize:])
+
+	data, err := unTx.Serialize(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.Equal(t, unTxData, data)
+
+	buf := make([]byte, len(data))
+	bytesWritten, err := unTx.SerializeInto(buf, iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.Equal(t, data, buf[:bytesWritten])
+
+	deserialized := &iota.UnsignedTransaction{}
+	bytesRead, err := deserialized.Deserialize(data, iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.Equal(t, len(data), bytesRead)
+	assert.Nil(t, deserialized.Payload)
+
+	reserialized, err := deserialized.Serialize(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.Equal(t, data, reserialized)
+}
+
+func TestUnsignedTransaction_CanonicalInputOrder(t *testing.T) {
+	unTx, _ := randUnsignedTransaction()
+	for len(unTx.Inputs) < 3 {
+		unTx, _ = randUnsignedTransaction()
+	}
+	rand.Shuffle(len(unTx.Inputs), func(i, j int) {
+		unTx.Inputs[i], unTx.Inputs[j] = unTx.Inputs[j], unTx.Inputs[i]
+	})
+	shuffled := append(iota.Serializables{}, unTx.Inputs...)
+
+	order, err := unTx.CanonicalInputOrder(iota.DeSeriModeNoValidation)
+	assert.NoError(t, err)
+	assert.Equal(t, shuffled, unTx.Inputs, "must not modify the transaction")
+
+	// compare against sorting the serialized inputs in place
+	sortedBytes := iota.LexicalOrderedByteSlices{}
+	for _, input := range unTx.Inputs {
+		inputBytes, err := input.Serialize(iota.DeSeriModeNoValidation)
+		assert.NoError(t, err)
+		sortedBytes = append(sortedBytes, inputBytes)
+	}
+	sort.Sort(sortedBytes)
+
+	ordered := iota.Serializables{}
+	for _, index := range order {
+		ordered = append(ordered, unTx.Inputs[index])
+	}
+	for i, input := range ordered {
+		inputBytes, err := input.Serialize(iota.DeSeriModeNoValidation)
+		assert.NoError(t, err)
+		assert.Equal(t, sortedBytes[i], inputBytes)
+	}
+}
+
+func TestUnsignedTransaction_SelfConsistent(t *testing.T) {
+	type test struct {
+		name   string
+		source func() *iota.UnsignedTransaction
+		mode   iota.DeSerializationMode
+		err    error
+	}
+	tests := []test{
+		{"ok", func() *iota.UnsignedTransaction {
+			unTx, _ := randUnsignedTransaction()
+			return unTx
+		}, iota.DeSeriModePerformValidation, nil},
+		{"ok with indexation payload", func() *iota.UnsignedTransaction {
+			unTx, _ := randUnsignedTransactionWithIndexationPayload(100)
+			return unTx
+		}, iota.DeSeriModePerformValidation, nil},
+		{"input of custom type", func() *iota.UnsignedTransaction {
+			unTx := oneInputOutputSignedTransactionPayload().Transaction.(*iota.UnsignedTransaction)
+			inputData, err := unTx.Inputs[0].Serialize(iota.DeSeriModeNoValidation)
+			assert.NoError(t, err)
+			unTx.Inputs[0] = stubSerializable(inputData)
+			return unTx
+		}, iota.DeSeriModeNoValidation, iota.ErrNonCanonicalSerialization},
+		{"output with trailing bytes", func() *iota.UnsignedTransaction {
+			unTx := oneInputOutputSignedTransactionPayload().Transaction.(*iota.UnsignedTransaction)
+			outputData, err := unTx.Outputs[0].Serialize(iota.DeSeriModeNoValidation)
+			assert.NoError(t, err)
+			unTx.Outputs[0] = stubSerializable(append(outputData, 1, 0, 0, 0))
+			return unTx
+		}, iota.DeSeriModeNoValidation, iota.ErrNonCanonicalSerialization},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.source().SelfConsistent(tt.mode)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "unexpected error %v", err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUnsignedTransaction_Sign(t *testing.T) {
+	seed := randEd25519Seed()
+	prvKey := ed25519.NewKeyFromSeed(seed[:])
+	addr := iota.AddressFromEd25519PubKey(prvKey.Public().(ed25519.PublicKey))
+
+	output, _ := randSigLockedSingleOutput(iota.AddressEd25519)
+	unTx := &iota.UnsignedTransaction{
+		Inputs: iota.Serializables{
+			&iota.UTXOInput{TransactionID: [iota.TransactionIDLength]byte{1}, TransactionOutputIndex: 0},
+			&iota.UTXOInput{TransactionID: [iota.TransactionIDLength]byte{2}, TransactionOutputIndex: 0},
+		},
+		Outputs: iota.Serializables{output},
+	}
+	// both inputs spend outputs of the same address
+	inputAddrs := []iota.Serializable{&addr, &addr}
+
+	unlockBlocks, err := unTx.Sign(inputAddrs, map[iota.Ed25519Address]ed25519.PrivateKey{addr: prvKey})
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, unlockBlocks, 2) {
+		return
+	}
+	assert.Equal(t, &iota.ReferenceUnlockBlock{Reference: 0}, unlockBlocks[1])
+
+	sig := unlockBlocks[0].(*iota.SignatureUnlockBlock).Signature.(*iota.Ed25519Signature)
+	assert.True(t, sig.AddressMatches(&addr))
+	essenceHash, err := unTx.EssenceHash()
+	assert.NoError(t, err)
+	valid, err := sig.Valid(essenceHash[:])
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	sigTxPay := &iota.SignedTransactionPayload{Transaction: unTx, UnlockBlocks: unlockBlocks}
+	_, err = sigTxPay.Serialize(iota.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+
+	t.Run("missing key", func(t *testing.T) {
+		unlockBlocks, err := unTx.Sign(inputAddrs, map[iota.Ed25519Address]ed25519.PrivateKey{})
+		assert.True(t, errors.Is(err, iota.ErrMissingSigningKey))
+		assert.Nil(t, unlockBlocks)
+	})
+}
+
+func TestUnsignedTransaction_Equal(t *testing.T) {
+	build := func() *iota.UnsignedTransaction {
+		addr := iota.Ed25519Address{1, 2, 3}
+		return &iota.UnsignedTransaction{
+			Inputs: iota.Serializables{
+				&iota.UTXOInput{TransactionID: [iota.TransactionIDLength]byte{1}, TransactionOutputIndex: 3},
+			},
+			Outputs: iota.Serializables{
+				&iota.SigLockedSingleOutput{Address: &addr, Amount: 1337},
+			},
+			Payload: &iota.IndexationPayload{Index: "index", Data: []byte{1, 2, 3}},
+		}
+	}
+
+	a, b := build(), build()
+	assert.True(t, a.Equal(b))
+	assert.True(t, b.Equal(a))
+
+	b.Outputs[0].(*iota.SigLockedSingleOutput).Amount++
+	assert.False(t, a.Equal(b))
+	assert.False(t, a.Equal(nil))
+}
+
+func TestUnsignedTransaction_SerializedSize(t *testing.T) {
+	unTx, _ := randUnsignedTransaction()
+	withPayload, _ := randUnsignedTransactionWithIndexationPayload(20)
+	withDustOutput, _ := randUnsignedTransaction()
+	dustOutput, _ := randSigLockedDustAllowanceOutput(iota.DustAllowanceMinimum)
+	withDustOutput.Outputs = append(withDustOutput.Outputs, dustOutput)
+	tests := []struct {
+		name string
+		unTx *iota.UnsignedTransaction
+	}{
+		{"without payload", unTx},
+		{"with indexation payload", withPayload},
+		{"with dust allowance output", withDustOutput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.unTx.Serialize(iota.DeSeriModeNoValidation)
+			if !assert.NoError(t, err) {
+				return
+			}
+			size, err := tt.unTx.SerializedSize()
+			assert.NoError(t, err)
+			assert.Equal(t, len(data), size)
+		})
+	}
+
+	_, err := (&iota.UnsignedTransaction{Outputs: iota.Serializables{&iota.SigLockedSingleOutput{}}}).SerializedSize()
+	assert.Error(t, err)
+}
+
+func TestUnsignedTransaction_HasPayload(t *testing.T) {
+	withoutPayload, _ := randUnsignedTransaction()
+	withEmptyPayload, _ := randUnsignedTransaction()
+	withEmptyPayload.Payload = &iota.IndexationPayload{Index: "index", Data: []byte{}}
+	tests := []struct {
+		name       string
+		unTx       *iota.UnsignedTransaction
+		hasPayload bool
+	}{
+		{"nil payload", withoutPayload, false},
+		{"indexation payload without data", withEmptyPayload, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.hasPayload, tt.unTx.HasPayload())
+			data, err := tt.unTx.Serialize(iota.DeSeriModePerformValidation)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			unTxTarget := &iota.UnsignedTransaction{}
+			bytesRead, err := unTxTarget.Deserialize(data, iota.DeSeriModePerformValidation)
+			assert.NoError(t, err)
+			assert.Len(t, data, bytesRead)
+			assert.Equal(t, tt.hasPayload, unTxTarget.HasPayload())
+			assert.EqualValues(t, tt.unTx, unTxTarget)
+		})
+	}
+}
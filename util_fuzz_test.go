@@ -0,0 +1,40 @@
+//go:build go1.18
+// +build go1.18
+
+package iota_test
+
+import (
+	"testing"
+
+	"github.com/luca-moser/iota"
+	"github.com/stretchr/testify/assert"
+)
+
+// fuzzDeserialize feeds arbitrary bytes into fresh objects produced by ctor, with and without validation.
+// Any successful validating parse must serialize back to exactly the consumed bytes and, if the object
+// is a BytesValidator, its ValidateBytes must consume the same amount of bytes.
+func fuzzDeserialize(f *testing.F, ctor func() iota.Serializable, seeds ...[]byte) {
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = ctor().Deserialize(data, iota.DeSeriModeNoValidation)
+
+		seri := ctor()
+		bytesRead, err := seri.Deserialize(data, iota.DeSeriModePerformValidation)
+		if err != nil {
+			return
+		}
+		reserialized, err := seri.Serialize(iota.DeSeriModePerformValidation)
+		if !assert.NoError(t, err, "a successfully deserialized object must serialize") {
+			return
+		}
+		assert.Equal(t, data[:bytesRead], reserialized)
+
+		if bytesValidator, ok := ctor().(iota.BytesValidator); ok {
+			bytesValidated, err := bytesValidator.ValidateBytes(data, iota.DeSeriModePerformValidation)
+			assert.NoError(t, err)
+			assert.Equal(t, bytesRead, bytesValidated)
+		}
+	})
+}
@@ -6,99 +6,132 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math/rand"
-	"sort"
-	"time"
+	"testing"
 
 	"github.com/luca-moser/iota"
+	"github.com/luca-moser/iota/testutil"
+	"github.com/stretchr/testify/assert"
 )
 
+// fullValidation validates everything including the lexical order of array elements.
+const fullValidation = iota.DeSeriModePerformValidation | iota.DeSeriModePerformLexicalOrdering
+
+// fixtures generates the objects of the tests. It is seeded with a fixed value so that runs are reproducible.
+// The rand* helpers below only build the expected serialized bytes of those objects by hand.
+var fixtures = testutil.New(rand.NewSource(1))
+
 func must(err error) {
 	if err != nil {
 		panic(err)
 	}
 }
 
+// AssertTruncationSafe asserts that deserializing every truncated prefix of data
+// into a fresh object produced by ctor returns an error instead of panicking,
+// with and without validation.
+func AssertTruncationSafe(t *testing.T, data []byte, ctor func() iota.Serializable) {
+	for _, deSeriMode := range []iota.DeSerializationMode{iota.DeSeriModePerformValidation, iota.DeSeriModeNoValidation} {
+		for i := 0; i < len(data); i++ {
+			assert.NotPanics(t, func() {
+				_, err := ctor().Deserialize(data[:i], deSeriMode)
+				assert.Errorf(t, err, "deserializing the first %d of %d bytes (mode %d) must fail", i, len(data), deSeriMode)
+			})
+		}
+	}
+}
+
+// AssertSameLexicalOrder asserts that a and b contain the same amount of elements
+// and that every element of a serializes to the same bytes as the element of b at the same index.
+func AssertSameLexicalOrder(t *testing.T, a iota.Serializables, b iota.Serializables, deSeriMode iota.DeSerializationMode) {
+	if !assert.Len(t, b, len(a), "both slices must contain the same amount of elements") {
+		return
+	}
+	for i := range a {
+		aData, err := a[i].Serialize(deSeriMode)
+		if !assert.NoError(t, err, "unable to serialize element %d of a", i) {
+			return
+		}
+		bData, err := b[i].Serialize(deSeriMode)
+		if !assert.NoError(t, err, "unable to serialize element %d of b", i) {
+			return
+		}
+		assert.Equal(t, aData, bData, "element %d differs", i)
+	}
+}
+
 // returns length amount random bytes
 func randBytes(length int) []byte {
-	var b []byte
-	for i := 0; i < length; i++ {
-		b = append(b, byte(rand.Intn(256)))
-	}
-	return b
+	return fixtures.Bytes(length)
 }
 
 func randTxHash() [iota.TransactionIDLength]byte {
-	var h [iota.TransactionIDLength]byte
-	b := randBytes(32)
-	copy(h[:], b)
-	return h
+	return fixtures.TransactionID()
 }
 
 func randWOTSAddr() (*iota.WOTSAddress, []byte) {
-	// type
-	wotsAddr := &iota.WOTSAddress{}
-	addr := randBytes(iota.WOTSAddressBytesLength)
-	copy(wotsAddr[:], addr)
-	// serialized
-	var b [iota.WOTSAddressSerializedBytesSize]byte
-	b[0] = iota.AddressWOTS
-	copy(b[iota.SmallTypeDenotationByteSize:], addr)
-	return wotsAddr, b[:]
+	addr := fixtures.WOTSAddress()
+	return addr, addressBytes(addr)
 }
 
 func randEd25519Addr() (*iota.Ed25519Address, []byte) {
-	// type
-	edAddr := &iota.Ed25519Address{}
-	addr := randBytes(iota.Ed25519AddressBytesLength)
-	copy(edAddr[:], addr)
-	// serialized
-	var b [iota.Ed25519AddressSerializedBytesSize]byte
-	b[0] = iota.AddressEd25519
-	copy(b[iota.SmallTypeDenotationByteSize:], addr)
-	return edAddr, b[:]
+	addr := fixtures.Ed25519Address()
+	return addr, addressBytes(addr)
+}
+
+func randAliasAddr() (*iota.AliasAddress, []byte) {
+	addr := fixtures.AliasAddress()
+	return addr, addressBytes(addr)
+}
+
+func randNFTAddr() (*iota.NFTAddress, []byte) {
+	addr := fixtures.NFTAddress()
+	return addr, addressBytes(addr)
+}
+
+// returns the serialized form of the given address
+func addressBytes(addr iota.Serializable) []byte {
+	switch a := addr.(type) {
+	case *iota.WOTSAddress:
+		return append([]byte{iota.AddressWOTS}, a[:]...)
+	case *iota.Ed25519Address:
+		return append([]byte{iota.AddressEd25519}, a[:]...)
+	case *iota.AliasAddress:
+		return append([]byte{iota.AddressAlias}, a[:]...)
+	case *iota.NFTAddress:
+		return append([]byte{iota.AddressNFT}, a[:]...)
+	default:
+		panic(fmt.Sprintf("invalid addr type: %T", addr))
+	}
 }
 
 func randEd25519Signature() (*iota.Ed25519Signature, []byte) {
-	// type
-	edSig := &iota.Ed25519Signature{}
-	pub := randBytes(ed25519.PublicKeySize)
-	sig := randBytes(ed25519.SignatureSize)
-	copy(edSig.PublicKey[:], pub)
-	copy(edSig.Signature[:], sig)
-	// serialized
+	edSig := fixtures.Ed25519Signature()
+	return edSig, ed25519SignatureBytes(edSig)
+}
+
+func ed25519SignatureBytes(edSig *iota.Ed25519Signature) []byte {
 	var b [iota.Ed25519SignatureSerializedBytesSize]byte
 	binary.LittleEndian.PutUint32(b[:iota.TypeDenotationByteSize], iota.SignatureEd25519)
-	copy(b[iota.TypeDenotationByteSize:], pub)
-	copy(b[iota.TypeDenotationByteSize+ed25519.PublicKeySize:], sig)
-	return edSig, b[:]
+	copy(b[iota.TypeDenotationByteSize:], edSig.PublicKey[:])
+	copy(b[iota.TypeDenotationByteSize+ed25519.PublicKeySize:], edSig.Signature[:])
+	return b[:]
 }
 
 func randLSTransactionUnspentOutputs(outputsCount int) *iota.LSTransactionUnspentOutputs {
-	return &iota.LSTransactionUnspentOutputs{
-		TransactionHash: randTxHash(),
-		UnspentOutputs: func() []*iota.LSUnspentOutput {
-			outputs := make([]*iota.LSUnspentOutput, outputsCount)
-			for i := 0; i < outputsCount; i++ {
-				addr, _ := randEd25519Addr()
-				outputs[i] = &iota.LSUnspentOutput{
-					Index:   uint16(i),
-					Address: addr,
-					Value:   uint64(rand.Intn(1000000) + 1),
-				}
-			}
-			return outputs
-		}(),
-	}
+	return fixtures.LSTransactionUnspentOutputs(outputsCount)
 }
 
 func randEd25519SignatureUnlockBlock() (*iota.SignatureUnlockBlock, []byte) {
-	edSig, edSigData := randEd25519Signature()
-	block := &iota.SignatureUnlockBlock{Signature: edSig}
-	return block, append([]byte{iota.UnlockBlockSignature}, edSigData...)
+	block := fixtures.SignatureUnlockBlock()
+	return block, signatureUnlockBlockBytes(block)
+}
+
+func signatureUnlockBlockBytes(block *iota.SignatureUnlockBlock) []byte {
+	return append([]byte{iota.UnlockBlockSignature}, ed25519SignatureBytes(block.Signature.(*iota.Ed25519Signature))...)
 }
 
 func randReferenceUnlockBlock() (*iota.ReferenceUnlockBlock, []byte) {
-	return referenceUnlockBlock(uint16(rand.Intn(1000)))
+	return referenceUnlockBlock(fixtures.ReferenceUnlockBlock().Reference)
 }
 
 func referenceUnlockBlock(index uint16) (*iota.ReferenceUnlockBlock, []byte) {
@@ -109,118 +142,154 @@ func referenceUnlockBlock(index uint16) (*iota.ReferenceUnlockBlock, []byte) {
 }
 
 func randUnsignedTransaction() (*iota.UnsignedTransaction, []byte) {
-	var buf bytes.Buffer
+	tx := fixtures.UnsignedTransaction(rand.Intn(10)+1, rand.Intn(10)+1)
+	return tx, unsignedTransactionBytes(tx)
+}
 
-	tx := &iota.UnsignedTransaction{}
-	must(binary.Write(&buf, binary.LittleEndian, iota.TransactionUnsigned))
+func randUnsignedTransactionWithIndexationPayload(dataLength int) (*iota.UnsignedTransaction, []byte) {
+	tx := fixtures.UnsignedTransaction(rand.Intn(10)+1, rand.Intn(10)+1)
+	tx.Payload = fixtures.IndexationPayload(dataLength)
+	return tx, unsignedTransactionBytes(tx)
+}
 
-	inputsBytes := iota.LexicalOrderedByteSlices{}
-	inputCount := rand.Intn(10) + 1
-	must(binary.Write(&buf, binary.LittleEndian, uint16(inputCount)))
-	for i := inputCount; i > 0; i-- {
-		_, inputData := randUTXOInput()
-		inputsBytes = append(inputsBytes, inputData)
-	}
+// returns the serialized form of the given unsigned transaction holding UTXO inputs, sig locked single outputs
+// and an optional indexation payload
+func unsignedTransactionBytes(tx *iota.UnsignedTransaction) []byte {
+	var buf bytes.Buffer
+	must(binary.Write(&buf, binary.LittleEndian, iota.TransactionUnsigned))
+	must(buf.WriteByte(iota.UnsignedTransactionFormatVersion))
 
-	sort.Sort(inputsBytes)
-	for _, inputData := range inputsBytes {
-		_, err := buf.Write(inputData)
+	must(binary.Write(&buf, binary.LittleEndian, uint16(len(tx.Inputs))))
+	for _, input := range tx.Inputs {
+		_, err := buf.Write(utxoInputBytes(input.(*iota.UTXOInput)))
 		must(err)
-		input := &iota.UTXOInput{}
-		if _, err := input.Deserialize(inputData, iota.DeSeriModePerformValidation); err != nil {
-			panic(err)
-		}
-		tx.Inputs = append(tx.Inputs, input)
 	}
 
-	outputsBytes := iota.LexicalOrderedByteSlices{}
-	outputCount := rand.Intn(10) + 1
-	must(binary.Write(&buf, binary.LittleEndian, uint16(outputCount)))
-	for i := outputCount; i > 0; i-- {
-		_, depData := randSigLockedSingleDeposit(iota.AddressEd25519)
-		outputsBytes = append(outputsBytes, depData)
+	must(binary.Write(&buf, binary.LittleEndian, uint16(len(tx.Outputs))))
+	for _, output := range tx.Outputs {
+		_, err := buf.Write(sigLockedSingleOutputBytes(output.(*iota.SigLockedSingleOutput)))
+		must(err)
 	}
 
-	sort.Sort(outputsBytes)
-	for _, outputData := range outputsBytes {
-		_, err := buf.Write(outputData)
+	switch payload := tx.Payload.(type) {
+	case nil:
+		// empty payload
+		must(binary.Write(&buf, binary.LittleEndian, uint32(0)))
+	case *iota.IndexationPayload:
+		payloadData := indexationPayloadBytes(payload)
+		must(binary.Write(&buf, binary.LittleEndian, uint32(len(payloadData))))
+		_, err := buf.Write(payloadData)
 		must(err)
-		output := &iota.SigLockedSingleDeposit{}
-		if _, err := output.Deserialize(outputData, iota.DeSeriModePerformValidation); err != nil {
-			panic(err)
-		}
-		tx.Outputs = append(tx.Outputs, output)
+	default:
+		panic(fmt.Sprintf("invalid unsigned transaction payload type: %T", payload))
 	}
 
-	// empty payload
-	must(binary.Write(&buf, binary.LittleEndian, uint32(0)))
+	return buf.Bytes()
+}
 
-	return tx, buf.Bytes()
+// returns count random parents in their lexical order
+func randSortedParents(count int) iota.SliceOfArraysOf32Bytes {
+	return fixtures.Parents(count)
 }
 
 func randMilestonePayload() (*iota.MilestonePayload, []byte) {
-	inclusionMerkleProof := randBytes(iota.MilestoneInclusionMerkleProofLength)
-	signature := randBytes(iota.MilestoneSignatureLength)
-	msPayload := &iota.MilestonePayload{
-		Index:     uint64(rand.Intn(1000)),
-		Timestamp: uint64(time.Now().Unix()),
-		InclusionMerkleProof: func() [iota.MilestoneInclusionMerkleProofLength]byte {
-			b := [iota.MilestoneInclusionMerkleProofLength]byte{}
-			copy(b[:], inclusionMerkleProof)
-			return b
-		}(),
-		Signature: func() [iota.MilestoneSignatureLength]byte {
-			b := [iota.MilestoneSignatureLength]byte{}
-			copy(b[:], signature)
-			return b
-		}(),
-	}
+	msPayload := fixtures.MilestonePayload()
 
 	var b bytes.Buffer
 	must(binary.Write(&b, binary.LittleEndian, iota.MilestonePayloadID))
 	must(binary.Write(&b, binary.LittleEndian, msPayload.Index))
 	must(binary.Write(&b, binary.LittleEndian, msPayload.Timestamp))
 
-	if _, err := b.Write(msPayload.InclusionMerkleProof[:]); err != nil {
-		panic(err)
+	must(binary.Write(&b, binary.LittleEndian, uint16(len(msPayload.Parents))))
+	for _, parent := range msPayload.Parents {
+		_, err := b.Write(parent[:])
+		must(err)
 	}
 
-	if _, err := b.Write(msPayload.Signature[:]); err != nil {
-		panic(err)
+	_, err := b.Write(msPayload.InclusionMerkleProof[:])
+	must(err)
+
+	must(binary.Write(&b, binary.LittleEndian, uint16(len(msPayload.Signatures))))
+	for _, sig := range msPayload.Signatures {
+		_, err := b.Write(sig[:])
+		must(err)
 	}
 
 	return msPayload, b.Bytes()
 }
 
-func randIndexationPayload(dataLength ...int) (*iota.IndexationPayload, []byte) {
-	const index = "寿司を作って"
+func randTreasuryTransaction() (*iota.TreasuryTransaction, []byte) {
+	treasuryTx := fixtures.TreasuryTransaction()
+	return treasuryTx, treasuryTransactionBytes(treasuryTx)
+}
 
-	var data []byte
-	switch {
-	case len(dataLength) > 0:
-		data = randBytes(dataLength[0])
-	default:
-		data = randBytes(rand.Intn(200) + 1)
-	}
+func treasuryTransactionBytes(treasuryTx *iota.TreasuryTransaction) []byte {
+	input := treasuryTx.Input.(*iota.TreasuryInput)
+	output := treasuryTx.Output.(*iota.TreasuryOutput)
+
+	var b bytes.Buffer
+	must(binary.Write(&b, binary.LittleEndian, iota.TreasuryTransactionPayloadID))
+	must(b.WriteByte(iota.InputTreasury))
+	_, err := b.Write(input[:])
+	must(err)
+	must(b.WriteByte(iota.OutputTreasuryOutput))
+	must(binary.Write(&b, binary.LittleEndian, output.Amount))
+
+	return b.Bytes()
+}
 
-	indexationPayload := &iota.IndexationPayload{Index: index, Data: data}
+// returns a receipt with fundsCount migrated funds entries in their lexical order
+func randReceiptPayload(fundsCount int) (*iota.ReceiptPayload, []byte) {
+	receipt := fixtures.ReceiptPayload(fundsCount)
 
 	var b bytes.Buffer
-	must(binary.Write(&b, binary.LittleEndian, iota.IndexationPayloadID))
+	must(binary.Write(&b, binary.LittleEndian, iota.ReceiptPayloadID))
+	must(binary.Write(&b, binary.LittleEndian, receipt.MigratedAt))
+	if receipt.Final {
+		must(b.WriteByte(1))
+	} else {
+		must(b.WriteByte(0))
+	}
+	must(binary.Write(&b, binary.LittleEndian, uint16(len(receipt.Funds))))
+	for _, seri := range receipt.Funds {
+		entry := seri.(*iota.MigratedFundsEntry)
+		_, err := b.Write(entry.TailTransactionHash[:])
+		must(err)
+		_, err = b.Write(addressBytes(entry.Address))
+		must(err)
+		must(binary.Write(&b, binary.LittleEndian, entry.Deposit))
+	}
+
+	treasuryTxData := treasuryTransactionBytes(receipt.Transaction.(*iota.TreasuryTransaction))
+	must(binary.Write(&b, binary.LittleEndian, uint32(len(treasuryTxData))))
+	_, err := b.Write(treasuryTxData)
+	must(err)
 
-	strLen := uint16(len(index))
-	must(binary.Write(&b, binary.LittleEndian, strLen))
+	return receipt, b.Bytes()
+}
 
-	if _, err := b.Write([]byte(index)); err != nil {
-		panic(err)
+func randIndexationPayload(dataLength ...int) (*iota.IndexationPayload, []byte) {
+	length := rand.Intn(200) + 1
+	if len(dataLength) > 0 {
+		length = dataLength[0]
 	}
+	indexationPayload := fixtures.IndexationPayload(length)
+	return indexationPayload, indexationPayloadBytes(indexationPayload)
+}
+
+func indexationPayloadBytes(indexationPayload *iota.IndexationPayload) []byte {
+	var b bytes.Buffer
+	must(binary.Write(&b, binary.LittleEndian, iota.IndexationPayloadID))
+
+	must(binary.Write(&b, binary.LittleEndian, uint16(len(indexationPayload.Index))))
+	_, err := b.Write([]byte(indexationPayload.Index))
+	must(err)
 
 	must(binary.Write(&b, binary.LittleEndian, uint32(len(indexationPayload.Data))))
-	if _, err := b.Write(indexationPayload.Data); err != nil {
-		panic(err)
-	}
+	_, err = b.Write(indexationPayload.Data)
+	must(err)
 
-	return indexationPayload, b.Bytes()
+	return b.Bytes()
 }
 
 func randMessage(withPayloadType uint32) (*iota.Message, []byte) {
@@ -234,150 +303,100 @@ func randMessage(withPayloadType uint32) (*iota.Message, []byte) {
 		payload, payloadData = randIndexationPayload()
 	}
 
-	m := &iota.Message{}
-	copy(m.Parent1[:], randBytes(iota.MessageHashLength))
-	copy(m.Parent2[:], randBytes(iota.MessageHashLength))
-	m.Payload = payload
-	m.Nonce = uint64(rand.Intn(1000))
+	m := fixtures.Message(payload)
 
 	var b bytes.Buffer
-	if err := b.WriteByte(iota.MessageVersion); err != nil {
-		panic(err)
-	}
-	if _, err := b.Write(m.Parent1[:]); err != nil {
-		panic(err)
-	}
-	if _, err := b.Write(m.Parent2[:]); err != nil {
-		panic(err)
+	must(b.WriteByte(iota.MessageVersion))
+	must(binary.Write(&b, binary.LittleEndian, uint16(len(m.Parents))))
+	for _, parent := range m.Parents {
+		_, err := b.Write(parent[:])
+		must(err)
 	}
 
-	switch {
-	case payload == nil:
-		// zero length payload
-		if err := binary.Write(&b, binary.LittleEndian, uint32(0)); err != nil {
-			panic(err)
-		}
-	default:
-		if err := binary.Write(&b, binary.LittleEndian, uint32(len(payloadData))); err != nil {
-			panic(err)
-		}
-		if _, err := b.Write(payloadData); err != nil {
-			panic(err)
-		}
-	}
+	// a nil payload is written as a zero payload length
+	must(binary.Write(&b, binary.LittleEndian, uint32(len(payloadData))))
+	_, err := b.Write(payloadData)
+	must(err)
 
-	if err := binary.Write(&b, binary.LittleEndian, m.Nonce); err != nil {
-		panic(err)
-	}
+	must(binary.Write(&b, binary.LittleEndian, m.Nonce))
 	return m, b.Bytes()
 }
 
 func randSignedTransactionPayload() (*iota.SignedTransactionPayload, []byte) {
+	sigTxPayload := fixtures.SignedTransactionPayload(rand.Intn(10)+1, rand.Intn(10)+1)
+
 	var buf bytes.Buffer
 	must(binary.Write(&buf, binary.LittleEndian, iota.SignedTransactionPayloadID))
-
-	sigTxPayload := &iota.SignedTransactionPayload{}
-	unTx, unTxData := randUnsignedTransaction()
-	_, err := buf.Write(unTxData)
+	_, err := buf.Write(unsignedTransactionBytes(sigTxPayload.Transaction.(*iota.UnsignedTransaction)))
 	must(err)
-	sigTxPayload.Transaction = unTx
 
-	unlockBlocksCount := len(unTx.Inputs)
-	must(binary.Write(&buf, binary.LittleEndian, uint16(unlockBlocksCount)))
-	for i := unlockBlocksCount; i > 0; i-- {
-		unlockBlock, unlockBlockData := randEd25519SignatureUnlockBlock()
-		_, err := buf.Write(unlockBlockData)
+	must(binary.Write(&buf, binary.LittleEndian, uint16(len(sigTxPayload.UnlockBlocks))))
+	for _, unlockBlock := range sigTxPayload.UnlockBlocks {
+		_, err := buf.Write(signatureUnlockBlockBytes(unlockBlock.(*iota.SignatureUnlockBlock)))
 		must(err)
-		sigTxPayload.UnlockBlocks = append(sigTxPayload.UnlockBlocks, unlockBlock)
 	}
 
 	return sigTxPayload, buf.Bytes()
 }
 
 func randUTXOInput() (*iota.UTXOInput, []byte) {
-	utxoInput := &iota.UTXOInput{}
+	utxoInput := fixtures.UTXOInput()
+	return utxoInput, utxoInputBytes(utxoInput)
+}
+
+func utxoInputBytes(utxoInput *iota.UTXOInput) []byte {
 	var b [iota.UTXOInputSize]byte
 	b[0] = iota.InputUTXO
-
-	txID := randBytes(iota.TransactionIDLength)
-	copy(b[iota.SmallTypeDenotationByteSize:], txID)
-	copy(utxoInput.TransactionID[:], txID)
-
-	index := uint16(rand.Intn(iota.RefUTXOIndexMax))
-	binary.LittleEndian.PutUint16(b[len(b)-iota.UInt16ByteSize:], index)
-	utxoInput.TransactionOutputIndex = index
-	return utxoInput, b[:]
+	copy(b[iota.SmallTypeDenotationByteSize:], utxoInput.TransactionID[:])
+	binary.LittleEndian.PutUint16(b[len(b)-iota.UInt16ByteSize:], utxoInput.TransactionOutputIndex)
+	return b[:]
 }
 
-func randSigLockedSingleDeposit(addrType iota.AddressType) (*iota.SigLockedSingleDeposit, []byte) {
-	var buf bytes.Buffer
-	must(buf.WriteByte(iota.OutputSigLockedSingleDeposit))
-
-	dep := &iota.SigLockedSingleDeposit{}
-
-	var addrData []byte
+func randSigLockedSingleOutput(addrType iota.AddressType) (*iota.SigLockedSingleOutput, []byte) {
+	dep := fixtures.SigLockedSingleOutput()
 	switch addrType {
 	case iota.AddressWOTS:
-		dep.Address, addrData = randWOTSAddr()
+		dep.Address = fixtures.WOTSAddress()
 	case iota.AddressEd25519:
-		dep.Address, addrData = randEd25519Addr()
 	default:
 		panic(fmt.Sprintf("invalid addr type: %d", addrType))
 	}
+	return dep, sigLockedSingleOutputBytes(dep)
+}
 
-	_, err := buf.Write(addrData)
+func sigLockedSingleOutputBytes(dep *iota.SigLockedSingleOutput) []byte {
+	var buf bytes.Buffer
+	must(buf.WriteByte(iota.OutputSigLockedSingleOutput))
+	_, err := buf.Write(addressBytes(dep.Address))
 	must(err)
+	must(binary.Write(&buf, binary.LittleEndian, dep.Amount))
+	return buf.Bytes()
+}
 
-	amount := uint64(rand.Intn(10000))
-	must(binary.Write(&buf, binary.LittleEndian, amount))
+func randSigLockedDustAllowanceOutput(amount uint64) (*iota.SigLockedDustAllowanceOutput, []byte) {
+	dep := fixtures.SigLockedDustAllowanceOutput()
 	dep.Amount = amount
 
+	var buf bytes.Buffer
+	must(buf.WriteByte(iota.OutputSigLockedDustAllowanceOutput))
+	_, err := buf.Write(addressBytes(dep.Address))
+	must(err)
+	must(binary.Write(&buf, binary.LittleEndian, dep.Amount))
+
 	return dep, buf.Bytes()
 }
 
+// returns a signed transaction payload spending the output at index 0 of a random transaction to a single output depositing 1337
 func oneInputOutputSignedTransactionPayload() *iota.SignedTransactionPayload {
-	return &iota.SignedTransactionPayload{
-		Transaction: &iota.UnsignedTransaction{
-			Inputs: []iota.Serializable{
-				&iota.UTXOInput{
-					TransactionID: func() [iota.TransactionIDLength]byte {
-						var b [iota.TransactionIDLength]byte
-						copy(b[:], randBytes(iota.TransactionIDLength))
-						return b
-					}(),
-					TransactionOutputIndex: 0,
-				},
-			},
-			Outputs: []iota.Serializable{
-				&iota.SigLockedSingleDeposit{
-					Address: func() iota.Serializable {
-						edAddr, _ := randEd25519Addr()
-						return edAddr
-					}(),
-					Amount: 1337,
-				},
-			},
-			Payload: nil,
-		},
-		UnlockBlocks: []iota.Serializable{
-			&iota.SignatureUnlockBlock{
-				Signature: func() iota.Serializable {
-					edSig, _ := randEd25519Signature()
-					return edSig
-				}(),
-			},
-		},
-	}
+	sigTxPayload := fixtures.SignedTransactionPayload(1, 1)
+	unTx := sigTxPayload.Transaction.(*iota.UnsignedTransaction)
+	unTx.Inputs[0].(*iota.UTXOInput).TransactionOutputIndex = 0
+	unTx.Outputs[0].(*iota.SigLockedSingleOutput).Amount = 1337
+	return sigTxPayload
 }
 
 func randEd25519Seed() [ed25519.SeedSize]byte {
 	var b [ed25519.SeedSize]byte
-	read, err := rand.Read(b[:])
-	if read != ed25519.SeedSize {
-		panic(fmt.Sprintf("could not read %d required bytes from secure RNG", ed25519.SeedSize))
-	}
-	if err != nil {
-		panic(err)
-	}
+	copy(b[:], fixtures.Bytes(ed25519.SeedSize))
 	return b
 }